@@ -6,8 +6,10 @@ import (
 	"time"
 
 	"github.com/BrunoTulio/pgopher/internal/backup"
+	"github.com/BrunoTulio/pgopher/internal/catalog"
 	"github.com/BrunoTulio/pgopher/internal/config"
 	"github.com/BrunoTulio/pgopher/internal/database"
+	"github.com/BrunoTulio/pgopher/internal/hooks"
 	"github.com/BrunoTulio/pgopher/internal/lock"
 	"github.com/BrunoTulio/pgopher/internal/remote"
 	"github.com/spf13/cobra"
@@ -52,7 +54,7 @@ Examples:
   # Local + remote backup
   pgopher backup --local --provider dropbox
 
-  # Custom timeout (default: 30 minutes)
+  # Custom timeout (default: local.timeout)
   pgopher backup --timeout 60
 
   # With custom config file
@@ -64,11 +66,11 @@ func init() {
 	rootCmd.AddCommand(backupCmd)
 
 	backupCmd.Flags().StringVarP(&backupProvider, "provider", "p", "",
-		"remote provider (dropbox, gdrive, s3, mega, gcs)")
+		"remote provider name from the config (any rclone backend: s3, drive, sftp, b2...)")
 	backupCmd.Flags().BoolVarP(&backupLocal, "local", "l", false,
 		"keep local backup (default: false when using --provider)")
-	backupCmd.Flags().IntVarP(&backupTimeout, "timeout", "t", 30,
-		"timeout in minutes")
+	backupCmd.Flags().IntVarP(&backupTimeout, "timeout", "t", 0,
+		"timeout in minutes (default from local.timeout)")
 }
 
 func runBackup(cmd *cobra.Command, args []string) {
@@ -99,10 +101,14 @@ func runBackup(cmd *cobra.Command, args []string) {
 
 	remoteCfg := checkProvider(cfg)
 	lockMgr := lock.New()
-	backupService := backup.NewWithFnOptions(log, backup.WithConfig(cfg))
+	hooksRunner := hooks.New(cfg.Hooks, cfg.Database, log)
+	backupService := backup.NewWithFnOptions(log, backup.WithConfig(cfg), backup.WithHooks(hooksRunner))
 	notifierService := createNotifierService(cfg)
 
-	timeoutDuration := time.Duration(backupTimeout) * time.Minute
+	timeoutDuration := cfg.LocalBackup.TimeoutDuration()
+	if backupTimeout > 0 {
+		timeoutDuration = time.Duration(backupTimeout) * time.Minute
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), timeoutDuration)
 	defer cancel()
 
@@ -137,6 +143,8 @@ func runBackup(cmd *cobra.Command, args []string) {
 
 		provider, err := remote.NewProviderWithOptions( /*restoreService,*/ log,
 			remote.WithOptions(*remoteCfg, cfg.Database, cfg.EncryptionKey),
+			remote.WithHooks(hooksRunner),
+			remote.WithWorkDir(cfg.WorkDir),
 		)
 		if err != nil {
 			log.Fatalf("❌ Failed to initialize provider: %v", err)
@@ -150,6 +158,7 @@ func runBackup(cmd *cobra.Command, args []string) {
 			log.Fatalf("remote upload failed: %v", err)
 		}
 
+		catalog.NewWithOptions(log, catalog.WithConfig(cfg)).Invalidate(remoteCfg.Name)
 		log.Infof("✅ Uploaded to %s successfully!", remoteCfg.Name)
 		go func() {
 			_ = notifierService.Success(context.Background(), fmt.Sprintf("Backup uploaded to %s", remoteCfg.Name))
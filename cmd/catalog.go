@@ -0,0 +1,368 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/BrunoTulio/logr/adapters/zap.v1"
+	"github.com/BrunoTulio/pgopher/internal/catalog"
+	"github.com/BrunoTulio/pgopher/internal/manifest"
+	"github.com/BrunoTulio/pgopher/internal/remote"
+	"github.com/BrunoTulio/pgopher/internal/utils"
+	"github.com/rclone/rclone/fs"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	catalogOutput    string
+	catalogTimeout   int
+	catalogProviders []string
+	catalogSince     string
+	catalogUntil     string
+	catalogEncrypted bool
+	catalogMinSize   string
+	catalogSort      string
+	catalogForce     bool
+)
+
+// catalogCmd represents the catalog command
+var catalogCmd = &cobra.Command{
+	Use:     "catalog",
+	Aliases: []string{"ls"},
+	Short:   "List the backups of every destination",
+	Long: `List the backups of the local directory and every enabled provider in one view.
+The copies of the same backup (same ID, or same name for backups taken without a
+manifest) are grouped, with the destinations holding them. Backups kept in a single
+place are flagged: losing that destination loses them ("pgopher copy" replicates them).
+
+Remote listings come from the catalog index while fresh (catalog.ttl).
+
+Examples:
+  pgopher catalog
+  pgopher ls --provider s3 --since 2026-10-01 --sort size
+
+  # Encrypted backups over 1 GiB, as CSV
+  pgopher catalog --encrypted --min-size 1G -o csv
+
+  pgopher catalog -o json | jq '.backups[] | select(.single_copy)'
+
+  # Manifest details and manual deletion
+  pgopher catalog show 3fa2c1d0
+  pgopher catalog rm 3fa2c1d0 --provider dropbox`,
+	Args: cobra.NoArgs,
+	Run:  runCatalog,
+}
+
+// catalogShowCmd represents the catalog show command
+var catalogShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show the manifest and the copies of a backup",
+	Args:  cobra.ExactArgs(1),
+	Run:   runCatalogShow,
+}
+
+// catalogRmCmd represents the catalog rm command
+var catalogRmCmd = &cobra.Command{
+	Use:   "rm <id>",
+	Short: "Delete a backup from every destination or the --provider ones",
+	Long: `Delete every copy of a backup, with its manifest, after a confirmation prompt.
+A backup without manifest is only deleted where its ID was listed, copies of the same
+name elsewhere may be other dumps. Restrict the deletion to some destinations with --provider (repeatable). Copies under
+S3 object lock are kept until their retention expires.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runCatalogRm,
+}
+
+func init() {
+	rootCmd.AddCommand(catalogCmd)
+	catalogCmd.AddCommand(catalogShowCmd)
+	catalogCmd.AddCommand(catalogRmCmd)
+
+	catalogCmd.PersistentFlags().StringSliceVarP(&catalogProviders, "provider", "p", nil,
+		"destinations to look at: local or a provider name (repeatable), default all")
+	catalogCmd.PersistentFlags().IntVarP(&catalogTimeout, "timeout", "t", 10, "timeout in minutes")
+
+	catalogCmd.Flags().StringVarP(&catalogOutput, "output", "o", "table", "output format: table, json, yaml or csv")
+	catalogCmd.Flags().StringVar(&catalogSince, "since", "", "backups taken since this time (YYYY-MM-DD [HH:MM])")
+	catalogCmd.Flags().StringVar(&catalogUntil, "until", "", "backups taken until this time (YYYY-MM-DD [HH:MM])")
+	catalogCmd.Flags().BoolVar(&catalogEncrypted, "encrypted", false, "only encrypted backups")
+	catalogCmd.Flags().StringVar(&catalogMinSize, "min-size", "", "minimum size, ex: 500M, 2G (KiB without suffix)")
+	catalogCmd.Flags().StringVar(&catalogSort, "sort", catalog.SortNewest, "sort by newest, oldest, size or name")
+
+	catalogShowCmd.Flags().StringVarP(&catalogOutput, "output", "o", "table", "output format: table, json or yaml")
+
+	catalogRmCmd.Flags().BoolVar(&catalogForce, "force", false, "delete without confirmation")
+}
+
+func runCatalog(cmd *cobra.Command, args []string) {
+	if !slices.Contains([]string{"table", "json", "yaml", "csv"}, catalogOutput) {
+		log.Fatalf("❌ Invalid --output %q, use table, json, yaml or csv", catalogOutput)
+	}
+	query, err := catalogQuery()
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	_, view := loadCatalog(true)
+	view = view.Apply(query)
+
+	switch catalogOutput {
+	case "json":
+		err = printJSON(view)
+	case "yaml":
+		err = yaml.NewEncoder(os.Stdout).Encode(view)
+	case "csv":
+		err = printCatalogCSV(view)
+	default:
+		printCatalogTable(view)
+	}
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	printCatalogFailures(view)
+	if len(view.Failed) > 0 {
+		os.Exit(1)
+	}
+}
+
+func runCatalogShow(cmd *cobra.Command, args []string) {
+	if !slices.Contains([]string{"table", "json", "yaml"}, catalogOutput) {
+		log.Fatalf("❌ Invalid --output %q, use table, json or yaml", catalogOutput)
+	}
+
+	catalogService, view := loadCatalog(true)
+	backup, found := view.Find(args[0])
+	if !found {
+		printCatalogFailures(view)
+		log.Fatalf("❌ Backup %s not found", args[0])
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(catalogTimeout)*time.Minute)
+	defer cancel()
+
+	m, merr := catalogService.Manifest(ctx, backup)
+	if merr != nil && !errors.Is(merr, catalog.ErrNoManifest) {
+		log.Errorf("⚠️  Failed to read the manifest: %v", merr)
+	}
+
+	var err error
+	details := struct {
+		catalog.Backup `yaml:",inline"`
+		Manifest       *manifest.Manifest `json:"manifest,omitempty" yaml:"manifest,omitempty"`
+	}{backup, m}
+
+	switch catalogOutput {
+	case "json":
+		err = printJSON(details)
+	case "yaml":
+		err = yaml.NewEncoder(os.Stdout).Encode(details)
+	default:
+		printBackupDetails(backup, m)
+	}
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+}
+
+func runCatalogRm(cmd *cobra.Command, args []string) {
+	catalogService, view := loadCatalog(false)
+	backup, found := view.Find(args[0])
+	if !found {
+		printCatalogFailures(view)
+		log.Fatalf("❌ Backup %s not found", args[0])
+	}
+
+	copies := backup.Select(args[0])
+
+	fmt.Printf("Backup %s (%s, %s), the following objects will be deleted:\n", args[0], backup.Name, utils.FormatBytes(backup.Size))
+	for _, l := range copies {
+		fmt.Printf("  - %s: %s\n", l.Provider, l.Path)
+		if l.Manifest {
+			fmt.Printf("  - %s: %s\n", l.Provider, manifest.Name(l.Path))
+		}
+	}
+	if kept := len(backup.Copies) - len(copies); kept > 0 {
+		fmt.Printf("%d other copy(ies) without the same ID are left untouched\n", kept)
+	}
+	if len(view.Failed) > 0 {
+		fmt.Println("Destinations that could not be listed are left untouched:")
+		printCatalogFailures(view)
+	}
+
+	if !catalogForce && !utils.AskConfirmation("Type 'yes' to confirm deletion") {
+		log.Info("❌ Deletion cancelled")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(catalogTimeout)*time.Minute)
+	defer cancel()
+
+	failed := 0
+	for _, l := range copies {
+		err := catalogService.Delete(ctx, l)
+		switch {
+		case errors.Is(err, remote.ErrObjectLocked):
+			log.Warnf("🔒 %s: %v", l.Provider, err)
+			failed++
+		case err != nil:
+			log.Errorf("❌ %s: %v", l.Provider, err)
+			failed++
+		default:
+			log.Infof("🗑️  Deleted from %s", l.Provider)
+		}
+	}
+
+	if failed > 0 {
+		log.Fatalf("❌ %d of %d copies not deleted", failed, len(copies))
+	}
+	log.Infof("✅ Backup %s deleted", args[0])
+}
+
+// loadCatalog lists the --provider destinations (all by default), quiet keeps the
+// progress logs out of a listing printed on stdout
+func loadCatalog(quiet bool) (*catalog.Catalog, catalog.View) {
+	if quiet {
+		log = zap.New(
+			zap.WithConsole(true),
+			zap.WithConsoleLevel("ERROR"),
+			zap.WithConsoleFormatter("TEXT"),
+			zap.WithEnableCaller(false),
+		)
+	}
+
+	loadEnvIfExists()
+
+	cfg, err := loadConfigOrFail()
+	if err != nil {
+		log.Fatalf("Error loading config: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(catalogTimeout)*time.Minute)
+	defer cancel()
+
+	catalogService := catalog.NewWithOptions(log, catalog.WithConfig(cfg))
+	return catalogService, catalogService.View(ctx, catalogProviders...)
+}
+
+func catalogQuery() (catalog.Query, error) {
+	query := catalog.Query{Encrypted: catalogEncrypted, Sort: catalogSort}
+
+	if catalogSince != "" {
+		since, err := utils.ParseTime(catalogSince)
+		if err != nil {
+			return query, fmt.Errorf("--since: %w", err)
+		}
+		query.Since = since
+	}
+	if catalogUntil != "" {
+		until, err := utils.ParseTime(catalogUntil)
+		if err != nil {
+			return query, fmt.Errorf("--until: %w", err)
+		}
+		query.Until = until
+	}
+	if catalogMinSize != "" {
+		var size fs.SizeSuffix
+		if err := size.Set(catalogMinSize); err != nil {
+			return query, fmt.Errorf("--min-size: %w", err)
+		}
+		query.MinSize = int64(size)
+	}
+
+	return query, query.Validate()
+}
+
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func printCatalogTable(view catalog.View) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tNAME\tSIZE\tCREATED\tDESTINATIONS")
+	for _, b := range view.Backups {
+		destinations := strings.Join(b.Destinations(), ", ")
+		if b.SingleCopy {
+			destinations += " ⚠️  single copy"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			b.ShortID, b.Name, utils.FormatBytes(b.Size), b.ModTime, destinations)
+	}
+	_ = w.Flush()
+
+	fmt.Printf("\n%d backup(s) on %s, %d in a single destination\n",
+		len(view.Backups), strings.Join(view.Destinations, ", "), view.Unreplicated)
+}
+
+func printCatalogCSV(view catalog.View) error {
+	w := csv.NewWriter(os.Stdout)
+	_ = w.Write([]string{"id", "short_id", "name", "size", "time", "encrypted", "physical", "destinations", "single_copy"})
+	for _, b := range view.Backups {
+		_ = w.Write([]string{
+			b.ID,
+			b.ShortID,
+			b.Name,
+			strconv.FormatInt(b.Size, 10),
+			b.Time.Format(time.RFC3339),
+			strconv.FormatBool(b.Encrypted),
+			strconv.FormatBool(b.Physical),
+			strings.Join(b.Destinations(), ";"),
+			strconv.FormatBool(b.SingleCopy),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func printBackupDetails(b catalog.Backup, m *manifest.Manifest) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "ID:\t%s\n", valueOr(b.ID, "(none, backup taken without manifest)"))
+	_, _ = fmt.Fprintf(w, "Short ID:\t%s\n", b.ShortID)
+	_, _ = fmt.Fprintf(w, "Name:\t%s\n", b.Name)
+	_, _ = fmt.Fprintf(w, "Size:\t%s (%d bytes)\n", utils.FormatBytes(b.Size), b.Size)
+	_, _ = fmt.Fprintf(w, "Created:\t%s (%s ago)\n", b.ModTime, utils.FormatDuration(time.Since(b.Time)))
+	_, _ = fmt.Fprintf(w, "Encrypted:\t%t\n", b.Encrypted)
+	_, _ = fmt.Fprintf(w, "Physical:\t%t\n", b.Physical)
+	if m != nil {
+		_, _ = fmt.Fprintf(w, "Database:\t%s\n", m.Database)
+		_, _ = fmt.Fprintf(w, "Method:\t%s\n", m.Method)
+		_, _ = fmt.Fprintf(w, "SHA256:\t%s\n", m.SHA256)
+		_, _ = fmt.Fprintf(w, "Manifest created:\t%s\n", utils.FormatTime(m.CreatedAt))
+	}
+	_ = w.Flush()
+
+	fmt.Println("Copies:")
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, l := range b.Copies {
+		_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", l.Provider, l.Path, utils.FormatBytes(l.Size), l.ModTime)
+	}
+	_ = w.Flush()
+	if b.SingleCopy {
+		fmt.Println("⚠️  Single copy, \"pgopher copy\" replicates it")
+	}
+}
+
+func printCatalogFailures(view catalog.View) {
+	for name, err := range view.Failed {
+		_, _ = fmt.Fprintf(os.Stderr, "❌ %s: %s\n", name, err)
+	}
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/BrunoTulio/pgopher/internal/config"
 	"github.com/BrunoTulio/pgopher/internal/notify"
+	"github.com/BrunoTulio/pgopher/internal/remote"
 	"github.com/BrunoTulio/pgopher/internal/utils"
 	"github.com/joho/godotenv"
 )
@@ -37,6 +38,9 @@ func loadConfigOrFail() (*config.Config, error) {
 			return nil, fmt.Errorf("failed to load YAML config: %w", err)
 		}
 		utils.InitTimezone(cfg.MustLocation(), "2006-01-02 15:04:05")
+		if err := remote.ConfigureRclone(cfg.Rclone); err != nil {
+			return nil, fmt.Errorf("failed to configure rclone: %w", err)
+		}
 
 		return cfg, nil
 	}
@@ -47,6 +51,9 @@ func loadConfigOrFail() (*config.Config, error) {
 	}
 
 	utils.InitTimezone(cfg.MustLocation(), "2006-01-02 15:04:05")
+	if err := remote.ConfigureRclone(cfg.Rclone); err != nil {
+		return nil, fmt.Errorf("failed to configure rclone: %w", err)
+	}
 
 	return cfg, nil
 
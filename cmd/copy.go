@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/BrunoTulio/pgopher/internal/catalog"
+	"github.com/BrunoTulio/pgopher/internal/replicate"
+	"github.com/BrunoTulio/pgopher/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	copyFrom    string
+	copyTo      string
+	copyIDs     []string
+	copySince   string
+	copyAll     bool
+	copyTimeout int
+)
+
+// copyCmd represents the copy command
+var copyCmd = &cobra.Command{
+	Use:   "copy",
+	Short: "Copy backups between providers",
+	Long: `Copy backups from the local directory or a provider to another provider, keeping
+their names and modification times. Backups already on the destination (same manifest ID or,
+without manifest, same name, size and modification time) are skipped, so the command can be re-run to resume or to keep a mirror.
+
+The copy is server-side when both providers use the same backend and it allows it
+(ex: two S3 buckets reachable with the same credentials), otherwise the files are
+streamed through pgopher. rclone checks the size and checksums after every transfer.
+
+Without --id, --since or --all only the newest backup is copied.
+
+Examples:
+  # Seed a new provider with the whole history
+  pgopher copy --from dropbox --to s3 --all
+
+  # Copy the last week of local backups
+  pgopher copy --from local --to b2 --since 2026-10-01
+
+  # Copy specific backups (IDs from "pgopher restore --list")
+  pgopher copy --from s3 --to gdrive --id 3fa2c1d0 --id 91bb02e4
+
+Scheduled replication is configured in the "replication" section of the config.`,
+	Args: cobra.NoArgs,
+	Run:  runCopy,
+}
+
+func init() {
+	rootCmd.AddCommand(copyCmd)
+
+	copyCmd.Flags().StringVar(&copyFrom, "from", "", "source: local or a provider name")
+	copyCmd.Flags().StringVar(&copyTo, "to", "", "destination provider name")
+	copyCmd.Flags().StringSliceVar(&copyIDs, "id", nil, "backup ID to copy (repeatable)")
+	copyCmd.Flags().StringVar(&copySince, "since", "", "copy the backups taken since this time (YYYY-MM-DD [HH:MM])")
+	copyCmd.Flags().BoolVar(&copyAll, "all", false, "copy every backup")
+	copyCmd.Flags().IntVarP(&copyTimeout, "timeout", "t", 120, "timeout in minutes")
+
+	_ = copyCmd.MarkFlagRequired("from")
+	_ = copyCmd.MarkFlagRequired("to")
+}
+
+func runCopy(cmd *cobra.Command, args []string) {
+	loadEnvIfExists()
+
+	cfg, err := loadConfigOrFail()
+	if err != nil {
+		log.Fatalf("Error loading config: %v", err)
+	}
+
+	filter, err := copyFilter()
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	if copyTo == "local" {
+		log.Fatalf("❌ --to must be a provider, local backups are created by \"pgopher backup\"")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(copyTimeout)*time.Minute)
+	defer cancel()
+
+	catalogService := catalog.NewWithOptions(log, catalog.WithConfig(cfg))
+	replicator := replicate.NewWithOptions(catalogService, log, replicate.WithConfig(cfg))
+
+	result, err := replicator.Copy(ctx, copyFrom, copyTo, filter)
+	if err != nil {
+		log.Fatalf("❌ Copy failed: %v", err)
+	}
+
+	log.Infof("✅ %d backup(s) copied to %s (%s), %d already there",
+		result.Copied, copyTo, utils.FormatBytes(result.Bytes), result.Skipped)
+}
+
+func copyFilter() (replicate.Filter, error) {
+	count := 0
+	if len(copyIDs) > 0 {
+		count++
+	}
+	if copySince != "" {
+		count++
+	}
+	if copyAll {
+		count++
+	}
+	if count > 1 {
+		return replicate.Filter{}, fmt.Errorf("--id, --since and --all cannot be used together")
+	}
+
+	filter := replicate.Filter{IDs: copyIDs, All: copyAll}
+	if copySince != "" {
+		since, err := utils.ParseTime(copySince)
+		if err != nil {
+			return replicate.Filter{}, fmt.Errorf("--since: %w", err)
+		}
+		filter.Since = since
+	}
+	return filter, nil
+}
@@ -13,10 +13,16 @@ import (
 	"github.com/BrunoTulio/pgopher/internal/catalog"
 	"github.com/BrunoTulio/pgopher/internal/config"
 	"github.com/BrunoTulio/pgopher/internal/database"
+	"github.com/BrunoTulio/pgopher/internal/digest"
+	"github.com/BrunoTulio/pgopher/internal/hooks"
 	apphttp "github.com/BrunoTulio/pgopher/internal/http"
 	"github.com/BrunoTulio/pgopher/internal/lock"
 	"github.com/BrunoTulio/pgopher/internal/remote"
+	"github.com/BrunoTulio/pgopher/internal/replicate"
+	"github.com/BrunoTulio/pgopher/internal/restore"
+	"github.com/BrunoTulio/pgopher/internal/retention"
 	"github.com/BrunoTulio/pgopher/internal/scheduler"
+	"github.com/BrunoTulio/pgopher/internal/wal"
 	"github.com/spf13/cobra"
 )
 
@@ -79,7 +85,24 @@ func runDaemon(cmd *cobra.Command, args []string) {
 	}
 	log.Info("✅ Database connection successful")
 	lockMgr := lock.New()
-	backupService := backup.NewWithFnOptions(log, backup.WithConfig(cfg))
+	// runs are only kept for the digest, which trims them on every send
+	var recorder *digest.Recorder
+	if cfg.Notification.Digest.Enabled {
+		recorder = digest.NewRecorder()
+	}
+	hooksRunner := hooks.New(cfg.Hooks, cfg.Database, log)
+	backupService := backup.NewWithFnOptions(log,
+		backup.WithConfig(cfg),
+		backup.WithHooks(hooksRunner),
+		backup.WithOnRetentionRemove(func(file retention.BackupFile) {
+			recorder.RecordRemoval(digest.Removal{
+				Destination: "local",
+				Path:        file.Path,
+				Size:        file.Size,
+				Time:        time.Now(),
+			})
+		}),
+	)
 	catalogService := catalog.NewWithOptions(log, catalog.WithConfig(cfg))
 	notifierService := createNotifierService(cfg)
 
@@ -88,7 +111,7 @@ func runDaemon(cmd *cobra.Command, args []string) {
 			log.Warn("⚠️  Restore in progress, skipping scheduled local backup")
 		} else {
 			log.Info("Running initial backup...")
-			backupFile, err := runOnStartBackupLocal(backupService)
+			backupFile, err := runOnStartBackupLocal(backupService, cfg.LocalBackup.TimeoutDuration())
 			if err != nil {
 				log.Errorf("Initial backup failed: %v", err)
 			} else {
@@ -115,6 +138,8 @@ func runDaemon(cmd *cobra.Command, args []string) {
 
 			provider, err := remote.NewProviderWithOptions(log,
 				remote.WithOptions(providerCfg, cfg.Database, cfg.EncryptionKey),
+				remote.WithHooks(hooksRunner),
+				remote.WithWorkDir(cfg.WorkDir),
 			)
 			if err != nil {
 				log.Errorf("Failed to create provider %s: %v", providerCfg.Name, err)
@@ -133,6 +158,7 @@ func runDaemon(cmd *cobra.Command, args []string) {
 				continue
 			}
 
+			catalogService.Invalidate(providerCfg.Name)
 			log.Infof("✅ Backup to %s completed!", providerCfg.Name)
 			go func(name string) {
 				_ = notifierService.Success(ctx, fmt.Sprintf("✅ Backup to %s completed!", name))
@@ -140,12 +166,61 @@ func runDaemon(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	schedOpts := []func(*scheduler.Options){
+		scheduler.WithConfig(cfg),
+		scheduler.WithRecorder(recorder),
+		scheduler.WithCatalog(catalogService),
+		scheduler.WithHooks(hooksRunner),
+	}
+
+	if len(cfg.Replication) > 0 {
+		schedOpts = append(schedOpts, scheduler.WithReplicator(
+			replicate.NewWithOptions(catalogService, log, replicate.WithConfig(cfg))))
+	}
+
+	if cfg.Notification.Digest.Enabled {
+		digestService := digest.NewWithOptions(catalogService, recorder, notifierService, log, digest.WithConfig(cfg))
+		schedOpts = append(schedOpts, scheduler.WithDigest(digestService, cfg.Notification.Digest.Schedule))
+	}
+
+	// keeps the catalog index fresh for restore and the HTTP API
+	cleanups := []func(ctx context.Context){
+		func(ctx context.Context) {
+			if err := catalogService.Refresh(ctx); err != nil {
+				log.Errorf("❌ Catalog refresh failed: %v", err)
+			}
+		},
+	}
+	// swapped out databases are dropped whatever restore.swap says, "restore --swap"
+	// swaps without it
+	cleanups = append(cleanups, func(ctx context.Context) {
+		databases := []config.DatabaseConfig{cfg.Database}
+		for _, t := range cfg.RestoreTargets {
+			databases = append(databases, t.Database.WithDefaults(cfg.Database))
+		}
+		for _, db := range databases {
+			restore.NewWithOpts(catalogService, log,
+				restore.WithConfig(cfg),
+				restore.WithDatabase(db),
+			).CleanupOld(ctx)
+		}
+	})
+	if cfg.WAL.Enabled {
+		archive := wal.NewWithOptions(log, wal.WithConfig(cfg))
+		cleanups = append(cleanups, func(ctx context.Context) {
+			if err := archive.Prune(ctx, catalogService); err != nil {
+				log.Errorf("❌ WAL prune failed: %v", err)
+			}
+		})
+	}
+	schedOpts = append(schedOpts, scheduler.WithCleanup(cleanups...))
+
 	sched := scheduler.NewWithOptions(
 		backupService,
 		notifierService,
 		lockMgr,
 		log,
-		scheduler.WithConfig(cfg),
+		schedOpts...,
 	)
 
 	if err := sched.Start(); err != nil {
@@ -179,8 +254,8 @@ func runDaemon(cmd *cobra.Command, args []string) {
 
 }
 
-func runOnStartBackupLocal(backupService *backup.Local) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+func runOnStartBackupLocal(backupService *backup.Local, timeout time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 	return backupService.Run(ctx)
 }
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/BrunoTulio/pgopher/internal/catalog"
+	"github.com/BrunoTulio/pgopher/internal/export"
+	"github.com/spf13/cobra"
+)
+
+var (
+	downloadProvider   string
+	downloadOut        string
+	downloadDecrypt    bool
+	downloadDecompress bool
+	downloadToSQL      bool
+	downloadTimeout    int
+)
+
+// downloadCmd represents the download command
+var downloadCmd = &cobra.Command{
+	Use:   "download <id>",
+	Short: "Download a backup to a local path",
+	Long: `Download a backup from the local directory or a provider to a local path, without
+touching the configured database. The backup is kept as stored (compressed and, with an
+encryption key, encrypted) unless asked otherwise:
+
+  --decrypt     remove the encryption (.sql.gz.age -> .sql.gz)
+  --decompress  also remove the compression, leaving the pg_dump archive (.dump),
+                to load with pg_restore
+  --to-sql      also convert the archive to a plain SQL script with pg_restore -f
+                (without owners and grants), to load with psql
+
+When --out is a directory (default: current directory) the file is named after the
+backup, with the extensions of the removed layers adjusted.
+
+Examples:
+  # IDs from "pgopher catalog"
+  pgopher download 3fa2c1d0 --provider s3
+
+  pgopher download 3fa2c1d0 --provider s3 --to-sql --out /tmp/app.sql
+  psql -d app_dev -f /tmp/app.sql`,
+	Args: cobra.ExactArgs(1),
+	Run:  runDownload,
+}
+
+func init() {
+	rootCmd.AddCommand(downloadCmd)
+
+	downloadCmd.Flags().StringVarP(&downloadProvider, "provider", "p", "local", "provider to download from, local or a provider name")
+	downloadCmd.Flags().StringVarP(&downloadOut, "out", "o", "", "output file or directory (default: current directory)")
+	downloadCmd.Flags().BoolVar(&downloadDecrypt, "decrypt", false, "decrypt the backup")
+	downloadCmd.Flags().BoolVar(&downloadDecompress, "decompress", false, "decrypt and decompress the backup")
+	downloadCmd.Flags().BoolVar(&downloadToSQL, "to-sql", false, "convert the backup to a plain SQL script (requires pg_restore)")
+	downloadCmd.Flags().IntVarP(&downloadTimeout, "timeout", "t", 120, "timeout in minutes")
+}
+
+func runDownload(cmd *cobra.Command, args []string) {
+	loadEnvIfExists()
+
+	cfg, err := loadConfigOrFail()
+	if err != nil {
+		log.Fatalf("Error loading config: %v", err)
+	}
+
+	if downloadProvider == catalog.AllProviders {
+		log.Fatalf("❌ --provider must be local or a provider name")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(downloadTimeout)*time.Minute)
+	defer cancel()
+
+	catalogService := catalog.NewWithOptions(log, catalog.WithConfig(cfg))
+	exporter := export.NewWithOptions(catalogService, log,
+		export.WithConfig(cfg),
+		export.WithDecrypt(downloadDecrypt),
+		export.WithDecompress(downloadDecompress),
+		export.WithToSQL(downloadToSQL),
+	)
+
+	if _, err := exporter.Run(ctx, downloadProvider, args[0], downloadOut); err != nil {
+		log.Fatalf("❌ Download failed: %v", err)
+	}
+}
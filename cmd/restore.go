@@ -3,10 +3,15 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/BrunoTulio/pgopher/internal/catalog"
+	"github.com/BrunoTulio/pgopher/internal/config"
 	"github.com/BrunoTulio/pgopher/internal/database"
+	"github.com/BrunoTulio/pgopher/internal/hooks"
 	"github.com/BrunoTulio/pgopher/internal/lock"
 	"github.com/BrunoTulio/pgopher/internal/restore"
 	"github.com/BrunoTulio/pgopher/internal/utils"
@@ -19,6 +24,44 @@ var (
 	restoreLatest   bool
 	restoreList     bool
 	restoreForce    bool
+	restoreFile     string
+	restoreFrom     string
+	restoreBefore   string
+	restoreAt       string
+	restoreNth      int
+
+	restoreTables        []string
+	restoreSchemas       []string
+	restoreExcludeTables []string
+	restoreDataOnly      bool
+	restoreSchemaOnly    bool
+	restoreTOCFile       string
+	restoreListContents  bool
+
+	restoreTarget     string
+	restoreTargetDB   string
+	restoreTargetHost string
+	restoreTargetPort int
+	restoreTargetUser string
+	restoreCreate     bool
+
+	restoreSnapshot   bool
+	restoreNoSnapshot bool
+	restoreUndo       bool
+	restoreSwap       bool
+	restoreNoPost     bool
+
+	restoreDrain        bool
+	restoreTerminate    bool
+	restoreAllowApps    []string
+	restoreDrainTimeout time.Duration
+
+	restoreDataDir        string
+	restoreRestoreCommand string
+	restoreTargetTime     string
+	restoreTargetLSN      string
+
+	restoreTimeout time.Duration
 )
 
 // restoreCmd represents the restore command
@@ -36,15 +79,58 @@ Examples:
   # Restore by shortID
   pgopher restore --id abc123
 
-  # Restore from specific local file
+  # Restore from specific local file (copied from another environment)
   pgopher restore --file /backups/mydb_20251226_083000.sql.gz
 
+  # Restore from stdin or an URL
+  cat mydb.sql.gz.age | pgopher restore --file - --force
+  pgopher restore --file https://example.com/dumps/mydb.sql.gz
+
+  # Restore an ad-hoc object from a configured provider
+  pgopher restore --from s3:backups/db/staging-20251226.sql.gz
+
   # Restore latest local backup
   pgopher restore --latest
 
   # Restore from remote provider (latest)
   pgopher restore --provider s3 --latest
 
+  # Restore the last backup taken before an incident, searching every provider
+  pgopher restore --provider all --before "2026-10-01 03:00"
+
+  # Restore the second newest backup
+  pgopher restore --nth 2
+
+  # Bring back a single table after a bad migration
+  pgopher restore --latest --table orders
+
+  # Pick entries by hand: dump the TOC, comment out lines with ';', restore
+  pgopher restore --latest --list-contents > backup.toc
+  pgopher restore --latest --toc backup.toc
+
+  # Keep a snapshot of the current data, and roll back if the backup was wrong
+  pgopher restore --latest --snapshot
+  pgopher restore --undo
+
+  # Kick out the application before restoring, keeping the monitoring agent
+  pgopher restore --latest --terminate --allow-app pg_exporter
+
+  # Blue/green: production stays online during the restore, the swap takes seconds
+  pgopher restore --provider s3 --latest --swap
+
+  # Restore side-by-side into a new database for investigation
+  pgopher restore --latest --target-db mydb_investigation --create
+
+  # Refresh staging from production backups (restore_targets: in config)
+  pgopher restore --provider s3 --latest --target staging
+
+  # Unpack a physical backup (method: physical) into an empty data directory
+  pgopher restore --latest --data-dir /var/lib/postgresql/17/restore
+
+  # Point-in-time recovery (wal.enabled, archive_command = 'pgopher wal-push %p'),
+  # the newest base backup before the target is picked
+  pgopher restore --data-dir /var/lib/postgresql/17/restore --target-time "2026-10-01 02:59"
+
   # Force restore (skip connection checks)
   pgopher restore --id abc123 --force`,
 	Run: runRestore,
@@ -54,15 +140,79 @@ func init() {
 	rootCmd.AddCommand(restoreCmd)
 
 	restoreCmd.Flags().StringVar(&restoreID, "id", "",
-		"backup shortID from catalog")
+		"backup ID or short ID from catalog")
 	restoreCmd.Flags().StringVarP(&restoreProvider, "provider", "p", "local",
-		"provider to restore from (local, s3, gcs, azure)")
+		"provider to restore from (local, s3, gcs, azure), 'all' to search every enabled provider")
 	restoreCmd.Flags().BoolVar(&restoreLatest, "latest", false,
 		"restore the most recent backup")
+	restoreCmd.Flags().StringVar(&restoreBefore, "before", "",
+		"restore the newest backup taken at or before this time (\"YYYY-MM-DD HH:MM\")")
+	restoreCmd.Flags().StringVar(&restoreAt, "at", "",
+		"restore the backup closest to this time (\"YYYY-MM-DD HH:MM\")")
+	restoreCmd.Flags().IntVar(&restoreNth, "nth", 0,
+		"restore the Nth newest backup (1 = latest), combinable with --before")
 	restoreCmd.Flags().BoolVar(&restoreList, "list", false,
 		"list available backups")
 	restoreCmd.Flags().BoolVar(&restoreForce, "force", false,
 		"force restore without confirmation")
+	restoreCmd.Flags().StringVar(&restoreFile, "file", "",
+		"restore from a file path, '-' for stdin or an http(s) URL")
+	restoreCmd.Flags().StringVar(&restoreFrom, "from", "",
+		"restore an ad-hoc object from a configured provider (<provider>:<path>)")
+	restoreCmd.Flags().StringSliceVar(&restoreTables, "table", nil,
+		"restore only these tables (repeatable)")
+	restoreCmd.Flags().StringSliceVar(&restoreSchemas, "schema", nil,
+		"restore only these schemas (repeatable)")
+	restoreCmd.Flags().StringSliceVar(&restoreExcludeTables, "exclude-table", nil,
+		"skip these tables, 'table' or 'schema.table' (repeatable)")
+	restoreCmd.Flags().BoolVar(&restoreDataOnly, "data-only", false,
+		"restore only the data, existing tables are kept")
+	restoreCmd.Flags().BoolVar(&restoreSchemaOnly, "schema-only", false,
+		"restore only the schema, no data")
+	restoreCmd.Flags().StringVar(&restoreTOCFile, "toc", "",
+		"restore only the entries of a TOC file (pg_restore -L), see --list-contents")
+	restoreCmd.Flags().BoolVar(&restoreListContents, "list-contents", false,
+		"print the contents (TOC) of the selected backup instead of restoring")
+	restoreCmd.Flags().StringVar(&restoreTarget, "target", "",
+		"restore into a named target from restore_targets")
+	restoreCmd.Flags().StringVar(&restoreTargetDB, "target-db", "",
+		"restore into this database instead of the configured one")
+	restoreCmd.Flags().StringVar(&restoreTargetHost, "target-host", "",
+		"restore into a database on this host")
+	restoreCmd.Flags().IntVar(&restoreTargetPort, "target-port", 0,
+		"port of the target host")
+	restoreCmd.Flags().StringVar(&restoreTargetUser, "target-user", "",
+		"user for the target database (password from RESTORE_TARGET_PASSWORD)")
+	restoreCmd.Flags().BoolVar(&restoreCreate, "create", false,
+		"create the target database if it does not exist")
+	restoreCmd.Flags().BoolVar(&restoreSnapshot, "snapshot", false,
+		"back up the target database before restoring (default from restore.snapshot)")
+	restoreCmd.Flags().BoolVar(&restoreNoSnapshot, "no-snapshot", false,
+		"do not take a pre-restore snapshot")
+	restoreCmd.Flags().BoolVar(&restoreUndo, "undo", false,
+		"roll back the last restore from its pre-restore snapshot")
+	restoreCmd.Flags().BoolVar(&restoreDrain, "drain", false,
+		"revoke CONNECT and wait for the other sessions to end before restoring")
+	restoreCmd.Flags().BoolVar(&restoreTerminate, "terminate", false,
+		"terminate the other sessions before restoring (implies --drain)")
+	restoreCmd.Flags().StringSliceVar(&restoreAllowApps, "allow-app", nil,
+		"application name never terminated nor waited for (repeatable)")
+	restoreCmd.Flags().DurationVar(&restoreDrainTimeout, "drain-timeout", 0,
+		"max wait for the sessions to end (default from restore.drain.timeout)")
+	restoreCmd.Flags().BoolVar(&restoreNoPost, "no-post-restore", false,
+		"skip the post-restore steps (restore.post_restore)")
+	restoreCmd.Flags().BoolVar(&restoreSwap, "swap", false,
+		"blue/green: restore into a new database, then rename it over the target (default from restore.swap)")
+	restoreCmd.Flags().StringVar(&restoreDataDir, "data-dir", "",
+		"physical backups: unpack into this empty data directory, recovery runs on the next start")
+	restoreCmd.Flags().StringVar(&restoreRestoreCommand, "restore-command", "",
+		"physical backups: restore_command written to postgresql.auto.conf (default wal-fetch when wal.enabled, else 'false')")
+	restoreCmd.Flags().StringVar(&restoreTargetTime, "target-time", "",
+		"physical backups: recover up to this time, defaults the backup selection to --before <time>")
+	restoreCmd.Flags().DurationVar(&restoreTimeout, "timeout", 0,
+		"max duration of the restore (default 30m, 24h with --data-dir)")
+	restoreCmd.Flags().StringVar(&restoreTargetLSN, "target-lsn", "",
+		"physical backups: recover up to this LSN, ex: 0/3000060")
 
 }
 
@@ -79,7 +229,7 @@ func runRestore(cmd *cobra.Command, args []string) {
 	catalogService := catalog.NewWithOptions(log, catalog.WithConfig(cfg))
 
 	if restoreList {
-		if err := listAvailableBackups(catalogService, restoreProvider); err != nil {
+		if err := listAvailableBackups(catalogService, restoreProvider, cfg.Database.Name); err != nil {
 			log.Fatalf("Failed to list backups: %v", err)
 		}
 		return
@@ -89,6 +239,11 @@ func runRestore(cmd *cobra.Command, args []string) {
 		log.Fatalf("Invalid flags: %v", err)
 	}
 
+	if restoreListContents {
+		listRestoreContents(cfg, catalogService)
+		return
+	}
+
 	lockMgr := lock.New()
 	log.Info("🔒 Acquiring restore lock...")
 	if err := lockMgr.LockForRestore(); err != nil {
@@ -103,23 +258,51 @@ func runRestore(cmd *cobra.Command, args []string) {
 		}
 	}()
 
-	log.Infof("💾 Database: %s@%s:%d/%s",
-		cfg.Database.Username,
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.Name)
+	if restoreDataDir != "" {
+		restorePhysical(cfg, catalogService)
+		return
+	}
 
-	shortID, err := determineShortID(catalogService, restoreProvider)
+	targetDB, err := resolveRestoreTarget(cfg)
 	if err != nil {
-		log.Fatalf("Failed to determine backup: %v", err)
+		log.Fatalf("Invalid restore target: %v", err)
+	}
+
+	log.Infof("💾 Database: %s@%s:%d/%s",
+		targetDB.Username,
+		targetDB.Host,
+		targetDB.Port,
+		targetDB.Name)
+
+	if !targetDB.SameAs(cfg.Database) {
+		log.Infof("🎯 Restoring into a different target (source: %s:%d/%s)",
+			cfg.Database.Host, cfg.Database.Port, cfg.Database.Name)
 	}
 
-	log.Infof("📦 Selected backup shortID: %s", shortID)
+	var fromProvider, shortID string
+	if restoreUndo {
+		log.Info("⏪ Undo: restoring the last pre-restore snapshot")
+	} else {
+		fromProvider, shortID = selectRestoreSource(catalogService)
+	}
 
-	pgClient := database.NewClient(&cfg.Database)
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	pgClient := database.NewClient(&targetDB)
+	ctx, cancel := context.WithTimeout(context.Background(), restoreTimeoutDuration())
 	defer cancel()
 
+	created := false
+	if restoreCreate {
+		created, err = pgClient.CreateDatabase(ctx)
+		if err != nil {
+			log.Fatalf("Failed to create database %s: %v", targetDB.Name, err)
+		}
+		if created {
+			log.Infof("🆕 Database %s created", targetDB.Name)
+		} else {
+			log.Infof("Database %s already exists", targetDB.Name)
+		}
+	}
+
 	log.Info("Testing database connection...")
 	if err := pgClient.TestConnection(ctx); err != nil {
 		log.Fatalf("Database connection failed: %v", err)
@@ -127,7 +310,7 @@ func runRestore(cmd *cobra.Command, args []string) {
 	log.Info("✅ Database connection successful")
 
 	if !restoreForce {
-		if !checkAndConfirmRestore(ctx, pgClient) {
+		if !checkAndConfirmRestore(ctx, pgClient, cfg) {
 			log.Info("Restore cancelled by user")
 			return
 		}
@@ -135,16 +318,275 @@ func runRestore(cmd *cobra.Command, args []string) {
 		log.Warn("⚠️  Force mode enabled, skipping safety checks")
 	}
 
-	restoreService := restore.NewWithOpts(catalogService, log, restore.WithConfig(cfg))
+	restoreOpts := []restore.FnOptions{
+		restore.WithConfig(cfg),
+		restore.WithDatabase(targetDB),
+		restore.WithSelection(restoreSelection()),
+		restore.WithHooks(hooks.New(cfg.Hooks, targetDB, log)),
+	}
+	if restoreNoPost {
+		restoreOpts = append(restoreOpts, restore.WithoutPostRestore())
+	}
+
+	restoreService := restore.NewWithOpts(catalogService, log, restoreOpts...)
+
+	if restoreUndo {
+		release := drainConnections(ctx, cfg, pgClient)
+		err := restoreService.Undo(ctx)
+		release()
+		if err != nil {
+			logRestoreResult(restoreService.Result())
+			log.Fatalf("Undo failed: %v", err)
+		}
+		checkRestoreResult(restoreService.Result())
+		log.Info("✅ Rolled back to the pre-restore snapshot")
+		return
+	}
+
+	if swapEnabled(cfg) {
+		err := restoreService.Swap(ctx, func(ctx context.Context) error {
+			return runRestoreSource(ctx, restoreService, fromProvider, shortID)
+		})
+		if err != nil {
+			logRestoreResult(restoreService.Result())
+			log.Fatalf("Restore failed: %v", err)
+		}
+		checkRestoreResult(restoreService.Result())
+		log.Info("✅ Restore completed successfully!")
+		return
+	}
+
+	// a database created by --create is empty, there is nothing to roll back to
+	snapshot := snapshotEnabled(cfg) && !created
+	if snapshot {
+		if _, err := restoreService.Snapshot(ctx); err != nil {
+			log.Fatalf("Restore aborted, %v", err)
+		}
+	}
+
+	release := drainConnections(ctx, cfg, pgClient)
+	err = runRestoreSource(ctx, restoreService, fromProvider, shortID)
+	release()
+
+	if err != nil {
+		logRestoreResult(restoreService.Result())
+		if snapshot {
+			log.Error("⏪ Run 'pgopher restore --undo' to roll back to the pre-restore snapshot")
+		}
+		log.Fatalf("Restore failed: %v", err)
+	}
+
+	if snapshot {
+		log.Info("⏪ Run 'pgopher restore --undo' to roll back to the pre-restore snapshot")
+	}
+	checkRestoreResult(restoreService.Result())
+	log.Info("✅ Restore completed successfully!")
 
-	if err := restoreService.Run(ctx, restoreProvider, shortID); err != nil {
+}
+
+// restorePhysical unpacks a physical backup into --data-dir, the server is not involved:
+// no connection, drain, snapshot or post-restore steps
+func restorePhysical(cfg *config.Config, catalogService *catalog.Catalog) {
+	log.Infof("🗄️  Data directory: %s", restoreDataDir)
+	fromProvider, shortID := selectRestoreSource(catalogService)
+
+	if !restoreForce {
+		log.Warnf("⚠️  PostgreSQL must be stopped and %s must not be in use", restoreDataDir)
+		if !utils.AskConfirmation("Type 'yes' to confirm restore") {
+			log.Info("Restore cancelled by user")
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), restoreTimeoutDuration())
+	defer cancel()
+
+	var targetTime time.Time
+	if restoreTargetTime != "" {
+		targetTime, _ = utils.ParseTime(restoreTargetTime) // checked by validateRestoreFlags
+	}
+
+	restoreCommand := recoveryRestoreCommand(cfg)
+	if (restoreTargetTime != "" || restoreTargetLSN != "") && restoreCommand == "" {
+		log.Fatalf("Recovering to a target needs archived WAL: enable wal or set --restore-command")
+	}
+
+	restoreService := restore.NewWithOpts(catalogService, log,
+		restore.WithConfig(cfg),
+		restore.WithDataDir(restoreDataDir),
+		restore.WithRestoreCommand(restoreCommand),
+		restore.WithRecoveryTarget(targetTime, restoreTargetLSN),
+		restore.WithHooks(hooks.New(cfg.Hooks, cfg.Database, log)),
+	)
+
+	if err := runRestoreSource(ctx, restoreService, fromProvider, shortID); err != nil {
 		log.Fatalf("Restore failed: %v", err)
 	}
 	log.Info("✅ Restore completed successfully!")
+}
+
+// listRestoreContents prints the TOC of the selected backup, nothing is restored
+func listRestoreContents(cfg *config.Config, catalogService *catalog.Catalog) {
+	fromProvider, shortID := selectRestoreSource(catalogService)
+
+	ctx, cancel := context.WithTimeout(context.Background(), restoreTimeoutDuration())
+	defer cancel()
+
+	restoreService := restore.NewWithOpts(catalogService, log,
+		restore.WithConfig(cfg),
+		restore.WithListContents(os.Stdout),
+	)
+
+	if err := runRestoreSource(ctx, restoreService, fromProvider, shortID); err != nil {
+		log.Fatalf("Failed to list backup contents: %v", err)
+	}
+}
+
+// selectRestoreSource logs the selected source and returns its provider and shortID,
+// both are only used when restoring from the catalog
+func selectRestoreSource(catalogService *catalog.Catalog) (string, string) {
+	switch {
+	case restoreFile != "":
+		log.Infof("📦 Selected backup file: %s", restoreFile)
+		return "", ""
+	case restoreFrom != "":
+		log.Infof("📦 Selected remote object: %s", restoreFrom)
+		return "", ""
+	}
 
+	fromProvider, shortID, err := determineShortID(catalogService, restoreProvider)
+	if err != nil {
+		log.Fatalf("Failed to determine backup: %v", err)
+	}
+
+	log.Infof("📦 Selected backup shortID: %s (%s)", shortID, fromProvider)
+	return fromProvider, shortID
+}
+
+func runRestoreSource(ctx context.Context, restoreService *restore.Restore, fromProvider, shortID string) error {
+	switch {
+	case restoreFile != "":
+		return restoreService.RunFile(ctx, restoreFile)
+	case restoreFrom != "":
+		return restoreService.RunRemoteObject(ctx, restoreFrom)
+	default:
+		return restoreService.Run(ctx, fromProvider, shortID)
+	}
 }
 
-func checkAndConfirmRestore(ctx context.Context, pgClient *database.Client) bool {
+// logRestoreResult prints the post-restore steps of the last restore. Called explicitly:
+// log.Fatalf skips deferred calls
+func logRestoreResult(res restore.Result) {
+	if len(res.Steps) == 0 {
+		return
+	}
+
+	failed := res.Failed()
+	log.Infof("📋 Restore of %s took %s, %d/%d post-restore step(s) succeeded",
+		res.Database, utils.FormatDuration(res.Duration), len(res.Steps)-len(failed), len(res.Steps))
+	for _, step := range failed {
+		log.Warnf("   ❌ %s: %v", step.Name, step.Err)
+	}
+}
+
+// checkRestoreResult prints the post-restore steps and exits non-zero when one failed, the
+// data is restored but the database may not be ready (ex: missing grants or statistics)
+func checkRestoreResult(res restore.Result) {
+	logRestoreResult(res)
+
+	if failed := res.Failed(); len(failed) > 0 {
+		log.Fatalf("⚠️  Data restored into %s, but %d of %d post-restore step(s) failed",
+			res.Database, len(failed), len(res.Steps))
+	}
+}
+
+// restoreTimeoutDuration bounds the whole restore, unpacking a physical backup copies the
+// whole cluster
+func restoreTimeoutDuration() time.Duration {
+	switch {
+	case restoreTimeout > 0:
+		return restoreTimeout
+	case restoreDataDir != "":
+		return 24 * time.Hour
+	default:
+		return 30 * time.Minute
+	}
+}
+
+// swapEnabled is false for --undo and selective restores, both need the existing database
+func swapEnabled(cfg *config.Config) bool {
+	if restoreUndo || restoreSelection().IsPartial() {
+		return false
+	}
+	return restoreSwap || cfg.Restore.Swap
+}
+
+// snapshotEnabled is false in swap mode, the replaced database is kept anyway
+func snapshotEnabled(cfg *config.Config) bool {
+	if restoreNoSnapshot || swapEnabled(cfg) {
+		return false
+	}
+	return restoreSnapshot || cfg.Restore.Snapshot
+}
+
+func restoreSelection() restore.Selection {
+	return restore.Selection{
+		Tables:        restoreTables,
+		Schemas:       restoreSchemas,
+		ExcludeTables: restoreExcludeTables,
+		DataOnly:      restoreDataOnly,
+		SchemaOnly:    restoreSchemaOnly,
+		TOCFile:       restoreTOCFile,
+	}
+}
+
+// drainConnections clears the sessions of the target database when draining is enabled,
+// the returned function restores CONNECT and must be called once the restore ends
+func drainConnections(ctx context.Context, cfg *config.Config, pgClient *database.Client) func() {
+	opts, enabled := drainOptions(cfg)
+	if !enabled {
+		return func() {}
+	}
+
+	log.Infof("🚰 Draining connections to %s (timeout %s)...", pgClient.Name(), opts.Timeout)
+	if len(opts.AllowApps) > 0 {
+		log.Infof("   Allowed applications: %s", strings.Join(opts.AllowApps, ", "))
+	}
+
+	grant, err := pgClient.Drain(ctx, opts)
+	release := func() {
+		if err := grant(context.WithoutCancel(ctx)); err != nil {
+			log.Errorf("⚠️  Failed to restore CONNECT on %s: %v", pgClient.Name(), err)
+			return
+		}
+		log.Info("🔓 CONNECT privileges restored")
+	}
+
+	if err != nil {
+		release()
+		log.Fatalf("Failed to drain connections: %v", err)
+	}
+
+	log.Info("✅ No other sessions left")
+	return release
+}
+
+func drainOptions(cfg *config.Config) (database.DrainOptions, bool) {
+	drain := cfg.Restore.Drain
+
+	opts := database.DrainOptions{
+		Terminate: drain.Terminate || restoreTerminate,
+		AllowApps: append(append([]string{}, drain.AllowApps...), restoreAllowApps...),
+		Timeout:   drain.TimeoutDuration(),
+	}
+	if restoreDrainTimeout > 0 {
+		opts.Timeout = restoreDrainTimeout
+	}
+
+	return opts, drain.Enabled || restoreDrain || opts.Terminate
+}
+
+func checkAndConfirmRestore(ctx context.Context, pgClient *database.Client, cfg *config.Config) bool {
 	countConnections, err := pgClient.CountConnections(ctx)
 
 	if err != nil {
@@ -153,7 +595,17 @@ func checkAndConfirmRestore(ctx context.Context, pgClient *database.Client) bool
 
 	if countConnections > 0 {
 		log.Warnf("⚠️  WARNING: %d active connection(s) to database", countConnections)
-		log.Warn("⚠️  These connections will be terminated during restore!")
+		opts, drain := drainOptions(cfg)
+		switch {
+		case swapEnabled(cfg):
+			log.Warn("⚠️  These connections will be terminated when the databases are swapped!")
+		case opts.Terminate:
+			log.Warn("⚠️  These connections will be terminated before the restore!")
+		case drain:
+			log.Warnf("⚠️  The restore will wait up to %s for these connections to end", opts.Timeout)
+		default:
+			log.Warn("⚠️  These connections may block the restore, use --terminate to end them")
+		}
 
 		if err := showActiveConnections(pgClient, ctx); err != nil {
 			log.Fatalf("Failed to show active connections: %v", err)
@@ -162,18 +614,35 @@ func checkAndConfirmRestore(ctx context.Context, pgClient *database.Client) bool
 		log.Info("✅ No active connections found")
 	}
 
-	log.Warnf("⚠️  WARNING: This will REPLACE all data in database ")
+	log.Warnf("⚠️  WARNING: This will REPLACE all data in database %s", pgClient.Name())
 	log.Warn("⚠️  All existing data will be LOST!")
 
 	return utils.AskConfirmation("Type 'yes' to confirm restore")
 }
 
-func listAvailableBackups(catalog *catalog.Catalog, provider string) error {
-	log.Info("📋 Available local backups:")
+func listAvailableBackups(catalogService *catalog.Catalog, provider, database string) error {
+	log.Infof("📋 Available backups (%s):", provider)
 
-	backups, err := catalog.List(context.Background(), provider)
-	if err != nil {
-		return fmt.Errorf("failed to list backups: %w", err)
+	providers := []string{provider}
+	if provider == catalog.AllProviders {
+		providers = catalogService.Providers()
+	}
+
+	var backups []catalog.BackupFile
+	for _, name := range providers {
+		list, err := catalogService.List(context.Background(), name)
+		if err != nil {
+			return fmt.Errorf("failed to list backups: %w", err)
+		}
+		backups = append(backups, list...)
+	}
+
+	if provider == "local" || provider == catalog.AllProviders {
+		snapshots, err := catalogService.Snapshots(database)
+		if err != nil {
+			return fmt.Errorf("failed to list snapshots: %w", err)
+		}
+		backups = append(backups, snapshots...)
 	}
 
 	if len(backups) == 0 {
@@ -183,9 +652,16 @@ func listAvailableBackups(catalog *catalog.Catalog, provider string) error {
 
 	for i, b := range backups {
 		log.Infof("  %d. %s", i+1, b.Name)
+		log.Infof("     Provider: %s", b.Provider)
+		if b.Tag != "" {
+			log.Infof("     Tag: %s", b.Tag)
+		}
 		log.Infof("     Size: %s", utils.FormatBytes(b.Size))
-		log.Infof("     Created: %s", b.ModTime)
+		log.Infof("     Created: %s (%s ago)", b.ModTime, utils.FormatDuration(time.Since(b.Time)))
 		log.Infof("     ShortID: %s", b.ShortID)
+		if b.ID != "" {
+			log.Infof("     ID: %s", b.ID)
+		}
 		if i < len(backups)-1 {
 			fmt.Println()
 		}
@@ -194,53 +670,182 @@ func listAvailableBackups(catalog *catalog.Catalog, provider string) error {
 	return nil
 }
 
+// resolveRestoreTarget returns the database to restore into: the configured one,
+// a named restore target and/or the --target-* flags, empty fields inherited from the source
+func resolveRestoreTarget(cfg *config.Config) (config.DatabaseConfig, error) {
+	target := config.DatabaseConfig{}
+
+	if restoreTarget != "" {
+		t, err := cfg.FindRestoreTarget(restoreTarget)
+		if err != nil {
+			return config.DatabaseConfig{}, err
+		}
+		target = t.Database
+	}
+
+	if restoreTargetHost != "" {
+		target.Host = restoreTargetHost
+	}
+	if restoreTargetPort != 0 {
+		target.Port = restoreTargetPort
+	}
+	if restoreTargetUser != "" {
+		target.Username = restoreTargetUser
+		target.Password = os.Getenv("RESTORE_TARGET_PASSWORD")
+	}
+	if restoreTargetDB != "" {
+		target.Name = restoreTargetDB
+	}
+
+	return target.WithDefaults(cfg.Database), nil
+}
+
+var lsnPattern = regexp.MustCompile(`^[0-9A-Fa-f]{1,8}/[0-9A-Fa-f]{1,8}$`)
+
 func validateRestoreFlags() error {
 	if restoreList {
 		return nil
 	}
 
+	// PITR starts from the newest base backup taken before the target
+	if restoreTargetTime != "" && restoreID == "" && !restoreLatest && restoreBefore == "" &&
+		restoreAt == "" && restoreNth == 0 && restoreFile == "" && restoreFrom == "" {
+		restoreBefore = restoreTargetTime
+	}
+
 	count := 0
 
 	if restoreID != "" {
 		count++
 	}
-	if restoreLatest {
+	if restoreLatest || restoreBefore != "" || restoreAt != "" || restoreNth > 0 {
+		count++
+	}
+	if restoreFile != "" {
+		count++
+	}
+	if restoreFrom != "" {
+		count++
+	}
+	if restoreUndo {
 		count++
 	}
 
 	if count == 0 {
-		return fmt.Errorf("specify one of: --file, --id, or --latest")
+		return fmt.Errorf("specify one of: --file, --from, --id, --latest, --before, --at, --nth or --undo")
 	}
 
 	if count > 1 {
-		return fmt.Errorf("cannot specify multiple restore options (--file, --id, --latest)")
+		return fmt.Errorf("cannot specify multiple restore options (--file, --from, --id, --latest/--before/--at/--nth, --undo)")
+	}
+
+	if restoreSnapshot && restoreNoSnapshot {
+		return fmt.Errorf("--snapshot and --no-snapshot cannot be used together")
+	}
+
+	if restoreSwap && (restoreUndo || restoreSelection().IsPartial()) {
+		return fmt.Errorf("--swap restores a full copy, it cannot be combined with --undo or a selective restore")
+	}
+
+	if restoreUndo && (restoreListContents || restoreSelection().IsPartial()) {
+		return fmt.Errorf("--undo restores the whole snapshot, it cannot be combined with --list-contents or a selective restore")
+	}
+
+	if restoreAt != "" && (restoreBefore != "" || restoreNth > 0 || restoreLatest) {
+		return fmt.Errorf("--at cannot be combined with --latest, --before or --nth")
+	}
+
+	if restoreLatest && restoreNth > 1 {
+		return fmt.Errorf("--latest cannot be combined with --nth")
+	}
+
+	if restoreNth < 0 {
+		return fmt.Errorf("--nth must be 1 or greater")
+	}
+
+	if restoreID != "" && restoreProvider == catalog.AllProviders {
+		return fmt.Errorf("--id requires a specific --provider")
+	}
+
+	if restoreDataDir != "" && (restoreUndo || restoreSwap || restoreSnapshot || restoreCreate ||
+		restoreTarget != "" || restoreTargetDB != "" || restoreSelection().IsPartial() || restoreListContents) {
+		return fmt.Errorf("--data-dir restores a whole cluster, it cannot be combined with database options (--undo, --swap, --snapshot, --target*, --create, --list-contents or a selective restore)")
+	}
+
+	if (restoreRestoreCommand != "" || restoreTargetTime != "" || restoreTargetLSN != "") && restoreDataDir == "" {
+		return fmt.Errorf("--restore-command, --target-time and --target-lsn require --data-dir")
+	}
+
+	if restoreTargetTime != "" && restoreTargetLSN != "" {
+		return fmt.Errorf("--target-time and --target-lsn cannot be used together")
+	}
+
+	if restoreTargetTime != "" {
+		if _, err := utils.ParseTime(restoreTargetTime); err != nil {
+			return fmt.Errorf("--target-time: %w", err)
+		}
+	}
+
+	if restoreTargetLSN != "" && !lsnPattern.MatchString(restoreTargetLSN) {
+		return fmt.Errorf("--target-lsn must look like 0/3000060, got %s", restoreTargetLSN)
+	}
+
+	if restoreDataOnly && restoreSchemaOnly {
+		return fmt.Errorf("--data-only and --schema-only cannot be used together")
+	}
+
+	if restoreTOCFile != "" {
+		if _, err := os.Stat(restoreTOCFile); err != nil {
+			return fmt.Errorf("--toc: %w", err)
+		}
+	}
+
+	if restoreListContents {
+		return nil
+	}
+
+	if restoreFile == "-" && !restoreForce {
+		return fmt.Errorf("--file - reads the backup from stdin, use --force to skip the confirmation prompt")
 	}
 
 	return nil
 }
 
-func determineShortID(catalog *catalog.Catalog, provider string) (string, error) {
+// determineShortID returns the provider and shortID of the selected backup
+func determineShortID(catalogService *catalog.Catalog, provider string) (string, string, error) {
 	if restoreID != "" {
-		return restoreID, nil
+		return provider, restoreID, nil
 	}
 
-	ctx := context.Background()
-	backups, err := catalog.List(ctx, provider)
-	if err != nil {
-		return "", fmt.Errorf("failed to list backups: %w", err)
+	// a data directory can only be restored from a pg_basebackup archive
+	sel := catalog.Selector{Nth: restoreNth, Physical: restoreDataDir != ""}
+
+	if restoreBefore != "" {
+		t, err := utils.ParseTime(restoreBefore)
+		if err != nil {
+			return "", "", fmt.Errorf("--before: %w", err)
+		}
+		sel.Before = t
 	}
 
-	if len(backups) == 0 {
-		return "", fmt.Errorf("no backups found")
+	if restoreAt != "" {
+		t, err := utils.ParseTime(restoreAt)
+		if err != nil {
+			return "", "", fmt.Errorf("--at: %w", err)
+		}
+		sel.At = t
 	}
 
-	if restoreLatest {
-		latest := backups[0]
-		log.Infof("🕐 Selected latest backup: %s", latest.Name)
-		return latest.ShortID, nil
+	backup, err := catalogService.Select(context.Background(), provider, sel)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to select backup: %w", err)
 	}
 
-	return "", fmt.Errorf("no backup selection criteria specified")
+	log.Infof("🕐 Selected backup (%s): %s", sel, backup.Name)
+	log.Infof("   Provider: %s, created %s (%s ago)",
+		backup.Provider, backup.ModTime, utils.FormatDuration(time.Since(backup.Time)))
+
+	return backup.Provider, backup.ShortID, nil
 }
 
 func showActiveConnections(pgClient *database.Client, ctx context.Context) error {
@@ -28,15 +28,72 @@ database:
   password: ""
   name: ""
 
+restore:
+  snapshot: false   # back up the target database before a restore, roll back with "pgopher restore --undo"
+  snapshot_keep: 3  # pre-restore snapshots kept per database (in <local.dir>/pre-restore)
+  swap: false       # blue/green: restore into <db>_restore_<ts>, then rename it over <db>
+  keep_old_hours: 24 # the replaced database is kept as <db>_old_<ts>, dropped hourly by the daemon
+                     # with the <db>_restore_<ts> left by interrupted restores
+  drain:            # clear the sessions of the target before an in-place restore
+    enabled: false  # revoke CONNECT and wait for the sessions to end
+    terminate: false # pg_terminate_backend the remaining sessions
+    allow_apps: []  # application names left alone, ex: ["pg_exporter"]
+    timeout: 60     # seconds
+  post_restore:     # run after pg_restore, in this order (analyze last)
+    reset_sequences: false
+    refresh_matviews: false
+    # owner: "app"  # dumps are taken with --no-owner --no-acl
+    # grants:
+    #   - role: "readonly"
+    #     privileges: "SELECT"
+    #     sequences: "USAGE, SELECT"
+    #     schemas: ["public"]
+    # sql_files: ["./post-restore.sql"]
+    analyze: ""     # "" | analyze | vacuum_analyze
+
+# restore_targets: # used with "pgopher restore --target <name>", empty fields are inherited from database
+#   - name: "staging"
+#     database:
+#       host: "staging-db.internal"
+#       username: "postgres"
+#       password: ""
+#       name: "app_staging"
+
+scheduler:
+  overlap: "skip"     # skip | queue, when the previous run of a job is still running
+  max_concurrent: 0   # max jobs running at the same time, 0 = unlimited
+  jitter: 0           # max random delay (seconds) before remote jobs, spreads uploads
+  catch_up: false     # on startup, run jobs whose last backup is older than the schedule
+  retry:              # job level retries (overridable per local/provider with "retry:")
+    max_attempts: 1   # 1 = no retry
+    initial_delay: 30 # seconds, doubled after every attempt
+    max_delay: 1800   # seconds
+    retry_on: ["network", "timeout"] # network | timeout | dump | all
+
 local:
   dir: "./backups"
-  schedule: 
+  method: "logical" # logical (pg_dump) | physical (pg_basebackup of the whole cluster, restore with --data-dir)
+  # timeout: 1800   # seconds, default 1800 (logical) or 86400 (physical)
+  schedule: # "HH:MM", cron ("*/15 * * * *", "0 3 * * 1-5") or descriptors ("@hourly", "@every 4h")
     - "02:00"
     - "14:00"
   retention:
     # retention_days: 30
     # max_backups: 10
   enabled: true
+  # heartbeat: # dead-man's-switch pings (start / success / fail)
+  #   kind: "healthchecks" # healthchecks | uptime_kuma
+  #   url: "https://hc-ping.com/<uuid>"
+
+# rclone: # transfer tuning of every provider, backend options (storage class, encryption...) go in config
+#   log_level: "notice"              # process wide: error, notice, info, debug
+#   bwlimit: "10M"                   # per provider, "off", a rate or a timetable "08:00,512k 19:00,10M 23:00,off" (followed mid-transfer)
+#   transfers: 4
+#   buffer_size: "16M"
+#   streaming_upload_cutoff: "100M"
+#   connect_timeout: 60              # seconds
+#   timeout: 300                     # IO idle timeout, seconds
+#   low_level_retries: 10
 
 providers:
   - name: "s3"
@@ -46,8 +103,19 @@ providers:
       - "02:00"
       - "14:00"
     path: "backups/db" #bucket or bucket/folder
+    # method: "physical" # default logical
     maxVersions: 5
     timeout: 300 #seconds
+    # heartbeat:
+    #   kind: "uptime_kuma"
+    #   url: "https://kuma.example.com/api/push/<token>"
+    # rclone: # overrides the top level rclone tuning for this provider
+    #   bwlimit: "08:00,512k 23:00,off"
+    #   transfers: 8
+    # object_lock: # immutable uploads, the bucket needs object lock (and versioning) enabled
+    #   mode: "compliance" # governance | compliance
+    #   days: 30           # locked files are skipped by the WAL prune until they expire
+    #   legal_hold: false
     config:
       provider: "s3"
       access_key_id: ""
@@ -57,6 +125,10 @@ providers:
       # acl: ""
       # force_path_style: ""
       # no_check_bucket: ""
+      # storage_class: "STANDARD_IA"
+      # server_side_encryption: "AES256"
+      # chunk_size: "64Mi"
+      # upload_cutoff: "200Mi"
 
   - name: "drive"
     type: "drive"
@@ -112,6 +184,38 @@ providers:
       # location: ""
       # storage_class: ""
 
+  # Any rclone backend works (https://rclone.org/overview/), "type" is the rclone type and
+  # "config" takes its options, validated against rclone. Passwords must be obscured
+  # ("pgopher obscure"), REMOTE_<NAME>_<KEY> env vars override the keys set here.
+  # - name: "nas"
+  #   type: "sftp"
+  #   enabled: false
+  #   schedule: ["03:00"]
+  #   path: "/srv/backups"
+  #   config:
+  #     host: "nas.internal"
+  #     user: "backup"
+  #     key_file: "/etc/pgopher/id_ed25519"
+  # - name: "azure"
+  #   type: "azureblob"
+  #   path: "backups"   #container or container/folder
+  #   config:
+  #     account: ""
+  #     key: ""
+  # - name: "b2"
+  #   type: "b2"
+  #   path: "bucket/db"
+  #   config:
+  #     account: ""
+  #     key: ""
+  # - name: "nfs"
+  #   type: "local"     # local or mounted path
+  #   path: "/mnt/nfs/backups"
+  # - name: "vault"    # a remote of an existing rclone.conf, wrapped remotes (crypt, union, chunker) work
+  #   remote: "crypt-backups"              # or "crypt-backups:sub/dir"
+  #   rclone_config: "/etc/rclone/rclone.conf" # default rclone's own path, RCLONE_CONFIG_PASS decrypts it
+  #   path: "db"
+
 notification:
   success_enabled: true
   error_enabled: true
@@ -128,9 +232,52 @@ notification:
   discord_webhook_url: "" #https://discord.com/api/webhooks/...
   telegram_bot_token: "" 
   telegram_chat_id: ""
+  digest:
+    enabled: false
+    schedule: "0 8 * * *" # cron, ex: daily at 08:00 or "0 8 * * 1" weekly on Monday
+    stale_after_hours: 26 # warn when the newest backup of a destination is older
+
+# replication: # copies the backups missing on "to", also on demand with "pgopher copy"
+#   - name: "offsite"
+#     from: "local"        # local or a provider name
+#     to: "s3"
+#     schedule: ["04:00"]
+#     max_age: 7           # days, 0 = the whole history
+#     timeout: 3600        # seconds
+
+catalog: # last listing of every provider, backup IDs come from the manifest uploaded with each backup
+  index: ""  # default <local.dir>/.catalog.json
+  ttl: 60    # minutes a provider listing is reused (refreshed hourly by the daemon), -1 = always list
+
+wal: # continuous archiving for point-in-time recovery, with physical base backups (method: physical)
+  enabled: false   # archive_command = 'pgopher wal-push %p --config /etc/pgopher/config.yaml'
+  local: true      # keep segments in dir
+  dir: ""          # default <local.dir>/wal
+  providers: []    # provider names, segments go to <path>/wal, ex: ["s3"]
+  # segments older than the oldest physical backup are pruned hourly by the daemon ("pgopher wal-prune")
+
+# hooks: # shell commands (sh -c) or SQL, job details in PGOPHER_EVENT, PGOPHER_JOB, PGOPHER_JOB_TYPE,
+#        # PGOPHER_FILE, PGOPHER_FILE_SIZE, PGOPHER_PROVIDER, PGOPHER_DATABASE and PGOPHER_ERROR
+#   pre_backup:
+#     - name: "pause worker"
+#       command: "systemctl stop queue-worker"
+#       timeout: 30       # seconds, default 60
+#       on_error: "abort" # abort (default for pre hooks) | continue (default for the others)
+#     - sql: "CHECKPOINT"
+#   post_backup:
+#     - command: "systemctl start queue-worker"
+#     - command: 'scp "$PGOPHER_FILE" archive:/srv/backups/'
+#       only: ["local"]   # job names: local, provider names, restore
+#   on_failure: []
+#   pre_restore: []
+#   post_restore: []
 
 encryption_key: ""  #my-super-secret-key
 
+# work_dir: "/var/tmp/pgopher" # scratch space, default local.dir for local backups and the system temp dir for
+#                             # remote ones. A physical backup needs the uncompressed cluster size here (pg_basebackup
+#                             # tars, removed as they are bundled) plus the compressed backup in the output dir
+
 run_on_startup: false
 run_remote_on_startup: false
 `
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BrunoTulio/pgopher/internal/catalog"
+	"github.com/BrunoTulio/pgopher/internal/config"
+	"github.com/BrunoTulio/pgopher/internal/utils"
+	"github.com/BrunoTulio/pgopher/internal/wal"
+	"github.com/spf13/cobra"
+)
+
+var walTimeout int
+
+// walPushCmd represents the wal-push command
+var walPushCmd = &cobra.Command{
+	Use:   "wal-push <path>",
+	Short: "Archive a WAL file (archive_command)",
+	Long: `Compress, encrypt and ship a WAL file to the local archive and the providers
+listed in wal.providers. Meant to be called by PostgreSQL:
+
+  # postgresql.conf
+  archive_mode = on
+  archive_command = 'pgopher wal-push %p --config /etc/pgopher/config.yaml'
+
+Combined with physical base backups (method: physical) it enables point-in-time recovery:
+
+  pgopher restore --data-dir /var/lib/postgresql/17/main --target-time "2026-10-01 03:00"`,
+	Args: cobra.ExactArgs(1),
+	Run:  runWALPush,
+}
+
+// walFetchCmd represents the wal-fetch command
+var walFetchCmd = &cobra.Command{
+	Use:   "wal-fetch <file> <path>",
+	Short: "Restore a WAL file from the archive (restore_command)",
+	Long: `Fetch a WAL file from the local archive or the providers listed in wal.providers.
+Written by "pgopher restore --data-dir" when wal.enabled is set:
+
+  restore_command = 'pgopher wal-fetch %f %p --config /etc/pgopher/config.yaml'
+
+Exits with 1 when the file is not archived, as PostgreSQL expects.`,
+	Args: cobra.ExactArgs(2),
+	Run:  runWALFetch,
+}
+
+// walPruneCmd represents the wal-prune command
+var walPruneCmd = &cobra.Command{
+	Use:   "wal-prune",
+	Short: "Remove the WAL no kept base backup needs",
+	Long: `Remove archived WAL older than the oldest physical base backup in the catalog.
+The daemon runs it hourly when wal.enabled is set.`,
+	Args: cobra.NoArgs,
+	Run:  runWALPrune,
+}
+
+func init() {
+	rootCmd.AddCommand(walPushCmd)
+	rootCmd.AddCommand(walFetchCmd)
+	rootCmd.AddCommand(walPruneCmd)
+
+	for _, c := range []*cobra.Command{walPushCmd, walFetchCmd, walPruneCmd} {
+		c.Flags().IntVarP(&walTimeout, "timeout", "t", 10, "timeout in minutes")
+	}
+}
+
+func loadWALArchive() *wal.Archive {
+	loadEnvIfExists()
+
+	cfg, err := loadConfigOrFail()
+	if err != nil {
+		log.Fatalf("Error loading config: %v", err)
+	}
+
+	if !cfg.WAL.Enabled {
+		log.Fatalf("WAL archiving is disabled (wal.enabled)")
+	}
+
+	return wal.NewWithOptions(log, wal.WithConfig(cfg))
+}
+
+func runWALPush(cmd *cobra.Command, args []string) {
+	archive := loadWALArchive()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(walTimeout)*time.Minute)
+	defer cancel()
+
+	if err := archive.Push(ctx, args[0]); err != nil {
+		log.Fatalf("WAL push failed: %v", err)
+	}
+}
+
+func runWALFetch(cmd *cobra.Command, args []string) {
+	archive := loadWALArchive()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(walTimeout)*time.Minute)
+	defer cancel()
+
+	if err := archive.Fetch(ctx, args[0], args[1]); err != nil {
+		if errors.Is(err, wal.ErrNotFound) {
+			log.Infof("%v", err)
+			cancel()
+			os.Exit(1)
+		}
+		log.Fatalf("WAL fetch failed: %v", err)
+	}
+}
+
+func runWALPrune(cmd *cobra.Command, args []string) {
+	loadEnvIfExists()
+
+	cfg, err := loadConfigOrFail()
+	if err != nil {
+		log.Fatalf("Error loading config: %v", err)
+	}
+
+	if !cfg.WAL.Enabled {
+		log.Fatalf("WAL archiving is disabled (wal.enabled)")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(walTimeout)*time.Minute)
+	defer cancel()
+
+	catalogService := catalog.NewWithOptions(log, catalog.WithConfig(cfg))
+	if err := wal.NewWithOptions(log, wal.WithConfig(cfg)).Prune(ctx, catalogService); err != nil {
+		log.Fatalf("WAL prune failed: %v", err)
+	}
+}
+
+// walFetchCommand is the restore_command pointing back to this binary and config
+func walFetchCommand() string {
+	exe, err := os.Executable()
+	if err != nil {
+		exe = "pgopher"
+	}
+
+	command := shellQuote(exe) + " wal-fetch %f %p"
+	if utils.FileExists(cfgFile) {
+		if abs, err := filepath.Abs(cfgFile); err == nil {
+			command += " --config " + shellQuote(abs)
+		}
+	}
+	return command
+}
+
+// recoveryRestoreCommand returns the restore_command of a physical restore: --restore-command,
+// wal-fetch when WAL archiving is enabled, or empty (WAL from the backup only)
+func recoveryRestoreCommand(cfg *config.Config) string {
+	if restoreRestoreCommand != "" {
+		return restoreRestoreCommand
+	}
+	if cfg.WAL.Enabled {
+		return walFetchCommand()
+	}
+	return ""
+}
+
+func shellQuote(s string) string {
+	if !strings.ContainsAny(s, " '\"$\\") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
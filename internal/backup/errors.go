@@ -0,0 +1,71 @@
+package backup
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrConnection marks a dump that failed because the database could not be reached, the
+// scheduler retries it like a network error
+var ErrConnection = errors.New("database connection failed")
+
+// connectionFailures are the libpq messages of an unreachable server, authentication
+// failures are not among them
+var connectionFailures = []string{
+	"connection refused",
+	"could not connect to server",
+	"could not translate host name",
+	"server closed the connection unexpectedly",
+	"timeout expired",
+	"no route to host",
+	"network is unreachable",
+	"the database system is starting up",
+	"the database system is shutting down",
+}
+
+// streamStderr logs the stderr of a PostgreSQL client tool line by line. The channel
+// receives, once the output is consumed, whether it reported a connection failure
+func (b *Local) streamStderr(tool string, r io.Reader) <-chan bool {
+	done := make(chan bool, 1)
+
+	go func() {
+		connFailed := false
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 2*1024*1024) // 2MB max
+
+		for scanner.Scan() {
+			line := scanner.Text()
+			b.log.Infof("%s: %s", tool, line)
+
+			lower := strings.ToLower(line)
+			for _, msg := range connectionFailures {
+				if strings.Contains(lower, msg) {
+					connFailed = true
+				}
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			b.log.Errorf("Erro no scanner: %v", err)
+		}
+		done <- connFailed
+	}()
+
+	return done
+}
+
+// toolError wraps the failure of a PostgreSQL client tool. CommandContext kills the
+// process on timeout, its "signal: killed" would hide the deadline from the retry policy
+func toolError(ctx context.Context, tool string, err error, connFailed bool) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return fmt.Errorf("%s failed: %w (%v)", tool, ctxErr, err)
+	}
+	if connFailed {
+		return fmt.Errorf("%s failed: %w: %w", tool, ErrConnection, err)
+	}
+	return fmt.Errorf("%s failed: %w", tool, err)
+}
@@ -1,7 +1,6 @@
 package backup
 
 import (
-	"bufio"
 	"compress/gzip"
 	"context"
 	"fmt"
@@ -12,7 +11,10 @@ import (
 	"time"
 
 	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/pgopher/internal/config"
 	"github.com/BrunoTulio/pgopher/internal/encoder"
+	"github.com/BrunoTulio/pgopher/internal/hooks"
+	"github.com/BrunoTulio/pgopher/internal/manifest"
 	"github.com/BrunoTulio/pgopher/internal/retention"
 	"github.com/BrunoTulio/pgopher/internal/utils"
 )
@@ -43,6 +45,33 @@ func NewWithFnOptions(log logr.Logger, opts ...func(*Options)) *Local {
 }
 
 func (b *Local) Run(ctx context.Context) (string, error) {
+	job := hooks.Job{Name: "local", Type: "local", Database: b.opt.Database}
+
+	if err := b.opt.Hooks.Run(ctx, hooks.PreBackup, job); err != nil {
+		return "", fmt.Errorf("pre backup: %w", err)
+	}
+
+	file, err := b.run(ctx)
+	if err != nil {
+		job.Err = err
+		if hookErr := b.opt.Hooks.Run(ctx, hooks.OnFailure, job); hookErr != nil {
+			b.log.Errorf("⚠️  Failure hook: %v", hookErr)
+		}
+		return "", err
+	}
+
+	job.File = file
+	if info, err := os.Stat(file); err == nil {
+		job.Size = info.Size()
+	}
+	if err := b.opt.Hooks.Run(ctx, hooks.PostBackup, job); err != nil {
+		return file, fmt.Errorf("post backup: %w", err)
+	}
+
+	return file, nil
+}
+
+func (b *Local) run(ctx context.Context) (string, error) {
 	b.log.Info("starting backup local")
 
 	if err := os.MkdirAll(b.opt.OutputDir, os.ModePerm); err != nil {
@@ -58,7 +87,11 @@ func (b *Local) Run(ctx context.Context) (string, error) {
 
 	b.log.Infof("Backup file: %s", filename)
 	startTime := time.Now()
-	if err := b.executePgDump(ctx, f); err != nil {
+	if config.IsPhysical(b.opt.Method) {
+		if err := b.executePgBasebackup(ctx, f); err != nil {
+			return "", fmt.Errorf("pg_basebackup failed: %w", err)
+		}
+	} else if err := b.executePgDump(ctx, f); err != nil {
 		return "", fmt.Errorf("pg_dump failed: %w", err)
 	}
 	duration := time.Since(startTime)
@@ -72,8 +105,20 @@ func (b *Local) Run(ctx context.Context) (string, error) {
 		_ = os.Remove(f)
 		return "", fmt.Errorf("backup file is empty")
 	}
+	method := config.MethodLogical
+	if config.IsPhysical(b.opt.Method) {
+		method = config.MethodPhysical
+	}
+	m, err := manifest.Create(f, b.opt.Database.Name, method)
+	if err != nil {
+		b.log.Warnf("⚠️  Failed to write manifest: %v", err)
+	}
+
 	b.log.Infof("✅ Backup completed successfully")
 	b.log.Infof("   File: %s", filename)
+	if m != nil {
+		b.log.Infof("   ID: %s", m.ShortID())
+	}
 	b.log.Infof("   Size: %s", utils.FormatBytes(fileInfo.Size()))
 	b.log.Infof("   Duration: %s", duration.Round(time.Second))
 
@@ -93,43 +138,62 @@ func createRetention(log logr.Logger, opt *Options) *retention.Local {
 		retention.WithRetention(opt.Retention.MaxBackups, opt.Retention.RetentionDays),
 		retention.WithOutputDir(opt.OutputDir),
 		retention.WithDatabaseName(opt.Database.Name),
+		retention.WithOnRemove(opt.OnRetentionRemove),
 	)
 }
 
-func (b *Local) executePgDump(ctx context.Context, outputPath string) error {
+// createOutput opens outputPath behind the gzip and, when enabled, age writers.
+// close flushes and closes the writers in order and returns the first error.
+func (b *Local) createOutput(outputPath string) (io.Writer, func() error, error) {
 	outFile, err := os.Create(outputPath)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
-	defer func() {
-		_ = outFile.Close()
-	}()
 
-	var finalWriter io.WriteCloser = outFile
+	closers := []io.Closer{outFile}
+	var finalWriter io.Writer = outFile
 
 	if b.opt.IsEncryptEnabled() {
 		enc, err := encoder.NewEncryptor(b.opt.EncryptionKey)
-
 		if err != nil {
-			return fmt.Errorf("failed to create encryptor: %w", err)
+			_ = outFile.Close()
+			return nil, nil, fmt.Errorf("failed to create encryptor: %w", err)
 		}
 		ageWriter, err := enc.NewWriter(outFile)
 		if err != nil {
-			return fmt.Errorf("failed to create age writer: %w", err)
+			_ = outFile.Close()
+			return nil, nil, fmt.Errorf("failed to create age writer: %w", err)
 		}
 
-		defer func() {
-			_ = ageWriter.Close()
-		}()
-
+		closers = append([]io.Closer{ageWriter}, closers...)
 		finalWriter = ageWriter
 	}
 
 	gz := gzip.NewWriter(finalWriter)
 	gz.Name = filepath.Base(outputPath)
 	gz.ModTime = time.Now()
+	closers = append([]io.Closer{gz}, closers...)
+
+	closeAll := func() error {
+		var first error
+		for _, c := range closers {
+			if err := c.Close(); err != nil && first == nil {
+				first = err
+			}
+		}
+		return first
+	}
+
+	return gz, closeAll, nil
+}
+
+func (b *Local) executePgDump(ctx context.Context, outputPath string) error {
+	gz, closeOutput, err := b.createOutput(outputPath)
+	if err != nil {
+		return err
+	}
 	defer func() {
-		_ = gz.Close()
+		_ = closeOutput()
 	}()
 
 	args := []string{
@@ -160,22 +224,12 @@ func (b *Local) executePgDump(ctx context.Context, outputPath string) error {
 		return fmt.Errorf("failed to start pg_dump: %w", err)
 	}
 
-	go func() {
-		scanner := bufio.NewScanner(stderrPipe)
-		scanner.Buffer(make([]byte, 64*1024), 2*1024*1024) // 2MB max
-
-		for scanner.Scan() {
-			b.log.Infof("pg_dump: %s", scanner.Text())
-		}
-
-		if err := scanner.Err(); err != nil {
-			b.log.Errorf("Erro no scanner: %v", err)
-		}
-	}()
+	// the output must be consumed before Wait closes the pipe
+	connFailed := <-b.streamStderr("pg_dump", stderrPipe)
 
 	if err := cmd.Wait(); err != nil {
 		_ = os.Remove(outputPath)
-		return fmt.Errorf("pg_dump failed: %w", err)
+		return toolError(ctx, "pg_dump", err, connFailed)
 	}
 
 	return nil
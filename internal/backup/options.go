@@ -5,16 +5,22 @@ import (
 	"time"
 
 	"github.com/BrunoTulio/pgopher/internal/config"
+	"github.com/BrunoTulio/pgopher/internal/hooks"
+	"github.com/BrunoTulio/pgopher/internal/retention"
 )
 
 type (
 	FnOptions func(*Options)
 	Options   struct {
-		GenerateFileName func() string // File name (empty = generates with timestamp)
-		OutputDir        string        // Output directory (empty = uses config)
-		Retention        config.RetentionConfig
-		Database         config.DatabaseConfig
-		EncryptionKey    string
+		GenerateFileName  func() string // File name (empty = generates with timestamp)
+		OutputDir         string        // Output directory (empty = uses config)
+		Retention         config.RetentionConfig
+		Database          config.DatabaseConfig
+		EncryptionKey     string
+		OnRetentionRemove func(retention.BackupFile) // called for every backup removed by retention
+		Hooks             *hooks.Runner              // pre/post backup and failure hooks, nil = none
+		Method            string                     // config.MethodLogical (default) or config.MethodPhysical
+		WorkDir           string                     // pg_basebackup tars of physical backups, empty = next to the output
 	}
 )
 
@@ -22,14 +28,19 @@ func WithConfig(
 	cfg *config.Config,
 ) FnOptions {
 	return func(opt *Options) {
+		opt.Method = cfg.LocalBackup.Method
 		opt.GenerateFileName = func() string {
 			timestamp := time.Now().Format("20060102-150405")
+			if config.IsPhysical(cfg.LocalBackup.Method) {
+				return fmt.Sprintf("%s-physical-%s.tar.gz", cfg.Database.Name, timestamp)
+			}
 			return fmt.Sprintf("%s-%s.sql.gz", cfg.Database.Name, timestamp)
 		}
 		opt.OutputDir = cfg.LocalBackup.Dir
 		opt.Retention = cfg.LocalBackup.Retention
 		opt.Database = cfg.Database
 		opt.EncryptionKey = cfg.EncryptionKey
+		opt.WorkDir = cfg.WorkDir
 	}
 }
 
@@ -47,6 +58,12 @@ func WithOutputDir(dir string) FnOptions {
 	}
 }
 
+func WithWorkDir(dir string) FnOptions {
+	return func(opts *Options) {
+		opts.WorkDir = dir
+	}
+}
+
 func WithGenerateFileName(fn func() string) FnOptions {
 	return func(backupOptions *Options) {
 		backupOptions.GenerateFileName = fn
@@ -59,6 +76,30 @@ func WithEncryptionKey(encryptionKey string) FnOptions {
 	}
 }
 
+func WithOnRetentionRemove(fn func(retention.BackupFile)) FnOptions {
+	return func(opts *Options) {
+		opts.OnRetentionRemove = fn
+	}
+}
+
+func WithMethod(method string) FnOptions {
+	return func(opts *Options) {
+		opts.Method = method
+	}
+}
+
+func WithHooks(runner *hooks.Runner) FnOptions {
+	return func(opts *Options) {
+		opts.Hooks = runner
+	}
+}
+
+func WithRetention(retention config.RetentionConfig) FnOptions {
+	return func(opts *Options) {
+		opts.Retention = retention
+	}
+}
+
 func WithoutRetention() FnOptions {
 	return func(opts *Options) {
 		opts.Retention = config.RetentionConfig{
@@ -2,14 +2,18 @@ package catalog
 
 import (
 	"context"
-	"path"
-
+	"errors"
 	"fmt"
 	"os"
+	"path"
+	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/BrunoTulio/logr"
 	"github.com/BrunoTulio/pgopher/internal/config"
+	"github.com/BrunoTulio/pgopher/internal/manifest"
 	"github.com/BrunoTulio/pgopher/internal/remote"
 	"github.com/BrunoTulio/pgopher/internal/utils"
 )
@@ -20,15 +24,33 @@ type (
 		log logr.Logger
 	}
 	BackupFile struct {
-		ShortID   string
-		Name      string
-		Path      string
-		Size      int64
-		ModTime   string
-		Encrypted bool
+		ID        string    `json:"id,omitempty"` // from the manifest, empty for backups taken without one
+		ShortID   string    `json:"short_id"`
+		Provider  string    `json:"provider"` // "local" or the remote provider name
+		Name      string    `json:"name"`
+		Path      string    `json:"path"`
+		Size      int64     `json:"size"`
+		ModTime   string    `json:"mod_time"`
+		Time      time.Time `json:"time"` // raw modification time, used for sorting and age
+		Encrypted bool      `json:"encrypted"`
+		Physical  bool      `json:"physical"`      // pg_basebackup archive, restored into a data directory
+		Tag       string    `json:"tag,omitempty"` // TagPreRestore for pre-restore snapshots, empty for regular backups
 	}
 )
 
+// TagPreRestore marks the snapshots taken by restore before replacing a database
+const TagPreRestore = "pre-restore"
+
+// SnapshotDir is where pre-restore snapshots are kept, out of reach of the local retention
+func SnapshotDir(backupDir string) string {
+	return filepath.Join(backupDir, TagPreRestore)
+}
+
+// IsPhysical reports whether a backup file name is a pg_basebackup archive
+func IsPhysical(name string) bool {
+	return strings.Contains(name, ".tar.gz")
+}
+
 func New(log logr.Logger) *Catalog {
 	return &Catalog{}
 }
@@ -46,26 +68,132 @@ func NewWithOptions(log logr.Logger, opts ...func(*Options)) *Catalog {
 	}
 }
 
+// List returns the backups of a provider, newest first. Remote listings come from the
+// index while it is fresh, see Refresh and Invalidate
 func (c *Catalog) List(ctx context.Context, providerName string) ([]BackupFile, error) {
-	c.log.Infof("📂 Listing: %s", providerName)
+	if providerName == "local" {
+		c.log.Infof("📂 Listing: %s", providerName)
 
-	switch providerName {
-	case "local":
-		return c.listLocal()
-	default:
-		providerCfg, err := c.findProvider(providerName)
+		files, err := c.listLocal()
 		if err != nil {
-			return nil, fmt.Errorf("provider not found: %w", err)
+			return nil, err
 		}
-		return c.listRemote(ctx, providerCfg)
+		for i := range files {
+			files[i].Provider = providerName
+		}
+		sortNewestFirst(files)
+		return files, nil
+	}
+
+	providerCfg, err := c.findProvider(providerName)
+	if err != nil {
+		return nil, fmt.Errorf("provider not found: %w", err)
 	}
+
+	cached, fresh := c.cached(providerName)
+	if fresh {
+		c.log.Infof("📂 Listing: %s (index)", providerName)
+		return cached, nil
+	}
+
+	return c.refresh(ctx, providerCfg, cached)
+}
+
+// Refresh lists every enabled remote provider again and updates the index
+func (c *Catalog) Refresh(ctx context.Context) error {
+	var errs []error
+	for _, p := range c.opt.providers {
+		if !p.Enabled {
+			continue
+		}
+		cached, _ := c.cached(p.Name)
+		if _, err := c.refresh(ctx, p, cached); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// refresh lists a remote provider, reusing the IDs of the previous listing for unchanged files
+func (c *Catalog) refresh(ctx context.Context, provider config.RemoteProvider, previous []BackupFile) ([]BackupFile, error) {
+	c.log.Infof("📂 Listing: %s", provider.Name)
+
+	files, err := c.listRemote(ctx, provider, previous)
+	if err != nil {
+		return nil, err
+	}
+	for i := range files {
+		files[i].Provider = provider.Name
+	}
+	sortNewestFirst(files)
+
+	c.store(provider.Name, files)
+	return files, nil
+}
+
+// Providers returns "local" (when enabled) followed by the enabled remote providers
+func (c *Catalog) Providers() []string {
+	var names []string
+	if c.opt.localEnabled {
+		names = append(names, "local")
+	}
+	for _, p := range c.opt.providers {
+		if p.Enabled {
+			names = append(names, p.Name)
+		}
+	}
+	return names
+}
+
+func sortNewestFirst(files []BackupFile) {
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].Time.After(files[j].Time)
+	})
+}
+
+func sortBackupsNewestFirst(backups []Backup) {
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].Time.After(backups[j].Time)
+	})
+}
+
+// Snapshots returns the pre-restore snapshots of a database, newest first
+func (c *Catalog) Snapshots(database string) ([]BackupFile, error) {
+	files, err := c.listDir(SnapshotDir(c.opt.backupDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	snapshots := make([]BackupFile, 0, len(files))
+	for _, f := range files {
+		if !strings.HasPrefix(f.Name, database+"-"+TagPreRestore+"-") {
+			continue
+		}
+		f.Provider = "local"
+		f.Tag = TagPreRestore
+		snapshots = append(snapshots, f)
+	}
+	sortNewestFirst(snapshots)
+
+	return snapshots, nil
 }
 
 func (c *Catalog) listLocal() ([]BackupFile, error) {
-	entries, err := os.ReadDir(c.opt.backupDir)
+	files, err := c.listDir(c.opt.backupDir)
 	if err != nil {
 		return nil, fmt.Errorf("read local: %w", err)
 	}
+	return files, nil
+}
+
+func (c *Catalog) listDir(dir string) ([]BackupFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
 
 	var files []BackupFile
 	for _, entry := range entries {
@@ -82,19 +210,25 @@ func (c *Catalog) listLocal() ([]BackupFile, error) {
 		info, _ := entry.Info()
 		modTime := info.ModTime()
 
-		files = append(files, BackupFile{
+		file := BackupFile{
 			ShortID:   utils.GenerateShortID(entry.Name(), modTime),
 			Name:      entry.Name(),
-			Path:      path.Join(c.opt.backupDir, name),
+			Path:      path.Join(dir, name),
 			Size:      info.Size(),
 			ModTime:   utils.FormatTime(modTime),
+			Time:      modTime,
 			Encrypted: strings.HasSuffix(entry.Name(), ".age"),
-		})
+			Physical:  IsPhysical(entry.Name()),
+		}
+		if m, err := manifest.ReadFile(file.Path); err == nil {
+			file.setID(m.ID)
+		}
+		files = append(files, file)
 	}
 	return files, nil
 }
 
-func (c *Catalog) listRemote(ctx context.Context, provider config.RemoteProvider) ([]BackupFile, error) {
+func (c *Catalog) listRemote(ctx context.Context, provider config.RemoteProvider, previous []BackupFile) ([]BackupFile, error) {
 
 	fsys, err := remote.NewProviderWithOptions(c.log, remote.WithOptions(provider, c.opt.database,
 		c.opt.encryptKey))
@@ -107,21 +241,77 @@ func (c *Catalog) listRemote(ctx context.Context, provider config.RemoteProvider
 		return nil, fmt.Errorf("list remote: %w", err)
 	}
 
+	known := make(map[string]BackupFile, len(previous))
+	for _, f := range previous {
+		known[f.Path] = f
+	}
+
 	files := make([]BackupFile, 0, len(entries))
 	for _, entry := range entries {
 
-		files = append(files, BackupFile{
+		file := BackupFile{
 			ShortID:   utils.GenerateShortID(entry.Name, entry.ModTime),
 			Name:      path.Base(entry.Name),
 			Path:      entry.Name,
 			Size:      entry.Size,
 			ModTime:   utils.FormatTime(entry.ModTime),
+			Time:      entry.ModTime,
 			Encrypted: strings.HasSuffix(entry.Name, ".age"),
-		})
+			Physical:  IsPhysical(entry.Name),
+		}
+
+		// the manifest is only downloaded for files the index does not know yet
+		prev, ok := known[entry.Name]
+		switch {
+		case ok && prev.Size == entry.Size && prev.Time.Unix() == entry.ModTime.Unix() && (prev.ID != "" || !entry.Manifest):
+			file.setID(prev.ID)
+		case entry.Manifest:
+			if id, err := readRemoteID(ctx, fsys, file.Name); err != nil {
+				c.log.Warnf("⚠️  Manifest of %s: %v", file.Name, err)
+			} else {
+				file.setID(id)
+			}
+		}
+
+		files = append(files, file)
 	}
 	return files, nil
 }
 
+func readRemoteID(ctx context.Context, fsys *remote.Provider, name string) (string, error) {
+	m, err := readRemoteManifest(ctx, fsys, name)
+	if err != nil {
+		return "", err
+	}
+	return m.ID, nil
+}
+
+func readRemoteManifest(ctx context.Context, fsys *remote.Provider, name string) (*manifest.Manifest, error) {
+	r, err := fsys.Open(ctx, manifest.Name(name))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	return manifest.Read(r)
+}
+
+// setID sets the manifest ID, an empty id keeps the ShortID derived from name and time
+func (f *BackupFile) setID(id string) {
+	if id == "" {
+		return
+	}
+	f.ID = id
+	f.ShortID = manifest.ShortID(id)
+}
+
+// Matches reports whether id is the ID or the short ID of the backup
+func (f BackupFile) Matches(id string) bool {
+	return id != "" && (f.ShortID == id || f.ID == id)
+}
+
 func (c *Catalog) findProvider(name string) (config.RemoteProvider, error) {
 	for _, p := range c.opt.providers {
 		if p.Name == name && p.Enabled {
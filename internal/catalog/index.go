@@ -0,0 +1,129 @@
+package catalog
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// The index keeps the last listing of every remote provider in a JSON file, so restore,
+// the HTTP API and the scheduled jobs do not list (and download manifests from) the
+// providers on every call. The file is read again on each access, the CLI and the daemon
+// share it
+var indexMu sync.Mutex
+
+type (
+	index struct {
+		Providers map[string]*providerIndex `json:"providers"`
+	}
+
+	providerIndex struct {
+		RefreshedAt time.Time    `json:"refreshed_at"`
+		Stale       bool         `json:"stale"` // a backup or copy changed the provider since
+		Files       []BackupFile `json:"files"`
+	}
+)
+
+// cached returns the indexed files of a provider and whether they can be used as is
+func (c *Catalog) cached(providerName string) ([]BackupFile, bool) {
+	if c.opt.indexPath == "" {
+		return nil, false
+	}
+
+	indexMu.Lock()
+	defer indexMu.Unlock()
+
+	idx := c.loadIndex()
+	entry, ok := idx.Providers[providerName]
+	if !ok {
+		return nil, false
+	}
+
+	fresh := !entry.Stale && c.opt.ttl > 0 && time.Since(entry.RefreshedAt) < c.opt.ttl
+	return entry.Files, fresh
+}
+
+// store saves the listing of a provider, a failure only costs a new listing next time
+func (c *Catalog) store(providerName string, files []BackupFile) {
+	c.updateIndex(func(idx *index) {
+		idx.Providers[providerName] = &providerIndex{
+			RefreshedAt: time.Now(),
+			Files:       files,
+		}
+	})
+}
+
+// Invalidate marks the indexed listing of a provider as outdated, called after a backup
+// or a copy to it. The IDs already known are kept and reused by the next listing
+func (c *Catalog) Invalidate(providerName string) {
+	c.updateIndex(func(idx *index) {
+		if entry, ok := idx.Providers[providerName]; ok {
+			entry.Stale = true
+		}
+	})
+}
+
+func (c *Catalog) updateIndex(update func(idx *index)) {
+	if c.opt.indexPath == "" {
+		return
+	}
+
+	indexMu.Lock()
+	defer indexMu.Unlock()
+
+	idx := c.loadIndex()
+	update(idx)
+
+	if err := c.saveIndex(idx); err != nil {
+		c.log.Warnf("⚠️  Failed to save catalog index: %v", err)
+	}
+}
+
+func (c *Catalog) loadIndex() *index {
+	idx := &index{Providers: map[string]*providerIndex{}}
+
+	data, err := os.ReadFile(c.opt.indexPath)
+	if err != nil {
+		return idx
+	}
+	if err := json.Unmarshal(data, idx); err != nil {
+		c.log.Warnf("⚠️  Ignoring corrupt catalog index %s: %v", c.opt.indexPath, err)
+		return &index{Providers: map[string]*providerIndex{}}
+	}
+	if idx.Providers == nil {
+		idx.Providers = map[string]*providerIndex{}
+	}
+	return idx
+}
+
+// saveIndex writes to a temp file and renames it, readers never see a partial index
+func (c *Catalog) saveIndex(idx *index) error {
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.opt.indexPath), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.opt.indexPath), ".catalog-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), c.opt.indexPath)
+}
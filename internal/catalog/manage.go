@@ -0,0 +1,100 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/BrunoTulio/pgopher/internal/manifest"
+	"github.com/BrunoTulio/pgopher/internal/remote"
+	"github.com/rclone/rclone/fs"
+)
+
+// ErrNoManifest is returned by Manifest for backups taken before manifests were written
+var ErrNoManifest = errors.New("backup has no manifest")
+
+// Manifest reads the manifest of a backup from the first copy holding one
+func (c *Catalog) Manifest(ctx context.Context, b Backup) (*manifest.Manifest, error) {
+	if b.ID == "" {
+		return nil, ErrNoManifest
+	}
+
+	var errs []error
+	for _, l := range b.Copies {
+		m, err := c.readManifest(ctx, l)
+		if err == nil {
+			return m, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", l.Provider, err))
+	}
+	return nil, errors.Join(errs...)
+}
+
+func (c *Catalog) readManifest(ctx context.Context, l Location) (*manifest.Manifest, error) {
+	if l.Provider == "local" {
+		return manifest.ReadFile(l.Path)
+	}
+
+	fsys, err := c.remote(l.Provider)
+	if err != nil {
+		return nil, err
+	}
+	return readRemoteManifest(ctx, fsys, path.Base(l.Path))
+}
+
+// Open streams a backup file of the catalog
+func (c *Catalog) Open(ctx context.Context, f BackupFile) (io.ReadCloser, error) {
+	if f.Provider == "local" {
+		return os.Open(f.Path)
+	}
+
+	fsys, err := c.remote(f.Provider)
+	if err != nil {
+		return nil, err
+	}
+	return fsys.Open(ctx, path.Base(f.Path))
+}
+
+// Delete removes a copy of a backup and its manifest. Returns remote.ErrObjectLocked while
+// the copy is under object lock
+func (c *Catalog) Delete(ctx context.Context, l Location) error {
+	if l.Provider == "local" {
+		if err := os.Remove(l.Path); err != nil {
+			return err
+		}
+		_ = os.Remove(manifest.Name(l.Path))
+		return nil
+	}
+
+	fsys, err := c.remote(l.Provider)
+	if err != nil {
+		return err
+	}
+	defer c.Invalidate(l.Provider)
+
+	name := path.Base(l.Path)
+	if err := fsys.Delete(ctx, name); err != nil {
+		return err
+	}
+	if err := fsys.Delete(ctx, manifest.Name(name)); err != nil && !errors.Is(err, fs.ErrorObjectNotFound) {
+		c.log.Warnf("⚠️  Manifest of %s not removed: %v", name, err)
+	}
+	return nil
+}
+
+func (c *Catalog) remote(providerName string) (*remote.Provider, error) {
+	providerCfg, err := c.findProvider(providerName)
+	if err != nil {
+		return nil, fmt.Errorf("provider not found: %w", err)
+	}
+
+	fsys, err := remote.NewProviderWithOptions(c.log, remote.WithOptions(providerCfg, c.opt.database,
+		c.opt.encryptKey))
+	if err != nil {
+		return nil, fmt.Errorf("remote fs: %w", err)
+	}
+	return fsys, nil
+}
@@ -1,12 +1,19 @@
 package catalog
 
-import "github.com/BrunoTulio/pgopher/internal/config"
+import (
+	"time"
+
+	"github.com/BrunoTulio/pgopher/internal/config"
+)
 
 type Options struct {
-	database   config.DatabaseConfig
-	providers  []config.RemoteProvider
-	backupDir  string
-	encryptKey string
+	database     config.DatabaseConfig
+	providers    []config.RemoteProvider
+	backupDir    string
+	localEnabled bool
+	encryptKey   string
+	indexPath    string        // empty = no index, providers are always listed
+	ttl          time.Duration // how long an indexed listing is reused
 }
 
 func WithConfig(cfg *config.Config) func(opt *Options) {
@@ -14,6 +21,9 @@ func WithConfig(cfg *config.Config) func(opt *Options) {
 		opt.database = cfg.Database
 		opt.providers = cfg.RemoteProviders
 		opt.backupDir = cfg.LocalBackup.Dir
+		opt.localEnabled = cfg.LocalBackup.Enabled
 		opt.encryptKey = cfg.EncryptionKey
+		opt.indexPath = cfg.CatalogIndex()
+		opt.ttl = cfg.CatalogTTL()
 	}
 }
@@ -0,0 +1,85 @@
+package catalog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Sort orders of a Query
+const (
+	SortNewest = "newest"
+	SortOldest = "oldest"
+	SortSize   = "size" // largest first
+	SortName   = "name"
+)
+
+// Query filters and orders the backups of a View, zero fields do not filter
+type Query struct {
+	Since     time.Time
+	Until     time.Time
+	Encrypted bool  // only encrypted backups
+	MinSize   int64 // bytes
+	Sort      string
+}
+
+func (q Query) Validate() error {
+	switch q.Sort {
+	case "", SortNewest, SortOldest, SortSize, SortName:
+	default:
+		return fmt.Errorf("invalid sort %q, use %s, %s, %s or %s", q.Sort, SortNewest, SortOldest, SortSize, SortName)
+	}
+	if !q.Since.IsZero() && !q.Until.IsZero() && q.Until.Before(q.Since) {
+		return fmt.Errorf("until is before since")
+	}
+	return nil
+}
+
+// Apply returns the view with the matching backups only
+func (v View) Apply(q Query) View {
+	backups := make([]Backup, 0, len(v.Backups))
+	for _, b := range v.Backups {
+		if q.match(b) {
+			backups = append(backups, b)
+		}
+	}
+	q.sort(backups)
+
+	v.Backups = backups
+	v.countUnreplicated()
+	return v
+}
+
+func (q Query) match(b Backup) bool {
+	switch {
+	case !q.Since.IsZero() && b.Time.Before(q.Since):
+		return false
+	case !q.Until.IsZero() && b.Time.After(q.Until):
+		return false
+	case q.Encrypted && !b.Encrypted:
+		return false
+	case b.Size < q.MinSize:
+		return false
+	}
+	return true
+}
+
+func (q Query) sort(backups []Backup) {
+	switch q.Sort {
+	case SortOldest:
+		sort.SliceStable(backups, func(i, j int) bool {
+			return backups[i].Time.Before(backups[j].Time)
+		})
+	case SortSize:
+		sort.SliceStable(backups, func(i, j int) bool {
+			return backups[i].Size > backups[j].Size
+		})
+	case SortName:
+		sort.SliceStable(backups, func(i, j int) bool {
+			return strings.Compare(backups[i].Name, backups[j].Name) < 0
+		})
+	default:
+		sortBackupsNewestFirst(backups)
+	}
+}
@@ -0,0 +1,118 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/BrunoTulio/pgopher/internal/utils"
+)
+
+// AllProviders selects backups across local and every enabled remote provider
+const AllProviders = "all"
+
+// Selector describes which backup to pick, newest first:
+// the Nth backup (1 = latest) taken at or before Before, or the backup closest to At
+type Selector struct {
+	Before   time.Time
+	At       time.Time
+	Nth      int
+	Physical bool // only pg_basebackup archives, for a restore into a data directory
+}
+
+func (s Selector) String() string {
+	nth := s.Nth
+	if nth < 1 {
+		nth = 1
+	}
+
+	var desc string
+	switch {
+	case !s.At.IsZero():
+		desc = fmt.Sprintf("closest to %s", utils.FormatTime(s.At))
+	case !s.Before.IsZero():
+		desc = fmt.Sprintf("#%d before %s", nth, utils.FormatTime(s.Before))
+	default:
+		desc = fmt.Sprintf("#%d newest", nth)
+	}
+
+	if s.Physical {
+		desc += " (physical)"
+	}
+	return desc
+}
+
+// Select picks a backup from one provider, or from all of them with AllProviders
+func (c *Catalog) Select(ctx context.Context, providerName string, sel Selector) (BackupFile, error) {
+	providers := []string{providerName}
+	if providerName == AllProviders {
+		providers = c.Providers()
+	}
+
+	var files []BackupFile
+	for _, name := range providers {
+		list, err := c.List(ctx, name)
+		if err != nil {
+			if providerName == AllProviders {
+				c.log.Warnf("⚠️ Skipping %s: %v", name, err)
+				continue
+			}
+			return BackupFile{}, err
+		}
+		for _, f := range list {
+			if !sel.Physical || f.Physical {
+				files = append(files, f)
+			}
+		}
+	}
+	sortNewestFirst(files)
+
+	if len(files) == 0 && sel.Physical {
+		return BackupFile{}, fmt.Errorf("no physical backups found")
+	}
+	if len(files) == 0 {
+		return BackupFile{}, fmt.Errorf("no backups found")
+	}
+
+	if !sel.At.IsZero() {
+		return closestTo(files, sel.At), nil
+	}
+
+	nth := sel.Nth
+	if nth < 1 {
+		nth = 1
+	}
+
+	matched := 0
+	for _, f := range files {
+		if !sel.Before.IsZero() && f.Time.After(sel.Before) {
+			continue
+		}
+		matched++
+		if matched == nth {
+			return f, nil
+		}
+	}
+
+	if matched == 0 {
+		return BackupFile{}, fmt.Errorf("no backup matches %s", sel)
+	}
+	return BackupFile{}, fmt.Errorf("no backup matches %s, only %d candidate(s)", sel, matched)
+}
+
+func closestTo(files []BackupFile, at time.Time) BackupFile {
+	best := files[0]
+	for _, f := range files[1:] {
+		if absDuration(f.Time.Sub(at)) < absDuration(best.Time.Sub(at)) {
+			best = f
+		}
+	}
+	return best
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
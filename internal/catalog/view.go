@@ -0,0 +1,194 @@
+package catalog
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// View groups the copies of every backup across local and the enabled providers
+	View struct {
+		Destinations []string          `json:"destinations" yaml:"destinations"`             // listed, in Providers order
+		Failed       map[string]string `json:"failed,omitempty" yaml:"failed,omitempty"`     // destination -> listing error
+		Backups      []Backup          `json:"backups" yaml:"backups"`                       // newest first
+		Unreplicated int               `json:"unreplicated_count" yaml:"unreplicated_count"` // backups held by a single destination
+	}
+
+	// Backup is one backup and the destinations holding a copy of it
+	Backup struct {
+		ID         string     `json:"id,omitempty" yaml:"id,omitempty"`
+		ShortID    string     `json:"short_id" yaml:"short_id"`
+		Name       string     `json:"name" yaml:"name"`
+		Size       int64      `json:"size" yaml:"size"`
+		ModTime    string     `json:"mod_time" yaml:"mod_time"`
+		Time       time.Time  `json:"time" yaml:"time"` // oldest copy, the original backup
+		Encrypted  bool       `json:"encrypted" yaml:"encrypted"`
+		Physical   bool       `json:"physical" yaml:"physical"`
+		SingleCopy bool       `json:"single_copy" yaml:"single_copy"` // lost if its only destination is
+		Copies     []Location `json:"copies" yaml:"copies"`
+	}
+
+	// Location is a copy of a backup on a destination
+	Location struct {
+		ShortID  string    `json:"short_id" yaml:"short_id"` // derived from name and time when there is no manifest
+		Provider string    `json:"provider" yaml:"provider"`
+		Path     string    `json:"path" yaml:"path"`
+		Size     int64     `json:"size" yaml:"size"`
+		ModTime  string    `json:"mod_time" yaml:"mod_time"`
+		Time     time.Time `json:"time" yaml:"time"`
+		Manifest bool      `json:"manifest" yaml:"manifest"` // a manifest is stored next to the copy
+	}
+)
+
+// Destinations returns the destinations holding the backup
+func (b Backup) Destinations() []string {
+	names := make([]string, len(b.Copies))
+	for i, l := range b.Copies {
+		names[i] = l.Provider
+	}
+	return names
+}
+
+// fileKey identifies the copies of a backup without manifest, modification times are
+// compared to the second as some backends drop the rest
+type fileKey struct {
+	name string
+	size int64
+	time int64
+}
+
+func keyOf(f BackupFile) fileKey {
+	return fileKey{name: f.Name, size: f.Size, time: f.Time.Unix()}
+}
+
+// View lists the destinations (every one when none is given) and groups the copies of the
+// same backup, by manifest ID or, for backups without one, by name, size and modification
+// time: remote dumps may all carry the same name. A destination failing to list is reported
+// in Failed
+func (c *Catalog) View(ctx context.Context, destinations ...string) View {
+	view := View{Destinations: []string{}, Backups: []Backup{}}
+
+	var (
+		byID   = map[string]int{}
+		byFile = map[fileKey]int{}
+	)
+	add := func(f BackupFile) {
+		i, ok := -1, false
+		if f.ID != "" {
+			i, ok = byID[f.ID]
+		}
+		if !ok {
+			// a copy without manifest, or the original of a copy that got one
+			if j, found := byFile[keyOf(f)]; found && (f.ID == "" || view.Backups[j].ID == "") {
+				i, ok = j, true
+			}
+		}
+
+		location := Location{
+			ShortID:  f.ShortID,
+			Provider: f.Provider,
+			Path:     f.Path,
+			Size:     f.Size,
+			ModTime:  f.ModTime,
+			Time:     f.Time,
+			Manifest: f.ID != "",
+		}
+		if !ok {
+			view.Backups = append(view.Backups, Backup{
+				ID:        f.ID,
+				ShortID:   f.ShortID,
+				Name:      f.Name,
+				Size:      f.Size,
+				ModTime:   f.ModTime,
+				Time:      f.Time,
+				Encrypted: f.Encrypted,
+				Physical:  f.Physical,
+			})
+			i = len(view.Backups) - 1
+			byFile[keyOf(f)] = i
+		}
+
+		b := &view.Backups[i]
+		if b.ID == "" && f.ID != "" {
+			b.ID, b.ShortID = f.ID, f.ShortID
+		}
+		if f.Time.Before(b.Time) {
+			b.Time, b.ModTime = f.Time, f.ModTime
+		}
+		if b.ID != "" {
+			byID[b.ID] = i
+		}
+		b.Copies = append(b.Copies, location)
+	}
+
+	if len(destinations) == 0 {
+		destinations = c.Providers()
+	}
+
+	for _, name := range destinations {
+		files, err := c.List(ctx, name)
+		if err != nil {
+			c.log.Warnf("⚠️ Skipping %s: %v", name, err)
+			if view.Failed == nil {
+				view.Failed = map[string]string{}
+			}
+			view.Failed[name] = err.Error()
+			continue
+		}
+
+		view.Destinations = append(view.Destinations, name)
+		for _, f := range files {
+			add(f)
+		}
+	}
+
+	sortBackupsNewestFirst(view.Backups)
+	for i := range view.Backups {
+		view.Backups[i].SingleCopy = len(view.Backups[i].Copies) == 1
+	}
+	view.countUnreplicated()
+
+	return view
+}
+
+// Find returns the backup with the given ID or short ID, the short ID of any of its copies
+// is accepted for backups without manifest
+func (v View) Find(id string) (Backup, bool) {
+	for _, b := range v.Backups {
+		if (b.ID != "" && b.ID == id) || b.ShortID == id {
+			return b, true
+		}
+		for _, l := range b.Copies {
+			if l.ShortID == id {
+				return b, true
+			}
+		}
+	}
+	return Backup{}, false
+}
+
+// Select returns the copies an ID found by Find refers to: every copy for the manifest ID,
+// only the matching ones for the ID of a copy without manifest, which may not be the same
+// dump as the rest of the group
+func (b Backup) Select(id string) []Location {
+	if b.ID != "" && (b.ID == id || b.ShortID == id) {
+		return b.Copies
+	}
+
+	var copies []Location
+	for _, l := range b.Copies {
+		if l.ShortID == id {
+			copies = append(copies, l)
+		}
+	}
+	return copies
+}
+
+func (v *View) countUnreplicated() {
+	v.Unreplicated = 0
+	for _, b := range v.Backups {
+		if b.SingleCopy {
+			v.Unreplicated++
+		}
+	}
+}
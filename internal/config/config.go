@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"path/filepath"
 	"time"
 )
 
@@ -12,7 +13,16 @@ type Config struct {
 	LocalBackup        LocalBackupConfig  `yaml:"local"`
 	RemoteProviders    []RemoteProvider   `yaml:"providers"`
 	Notification       NotificationConfig `yaml:"notification"`
+	Scheduler          SchedulerConfig    `yaml:"scheduler"`
+	Restore            RestoreConfig      `yaml:"restore"`
+	Hooks              HooksConfig        `yaml:"hooks"`
+	WAL                WALConfig          `yaml:"wal"`
+	Rclone             RcloneSettings     `yaml:"rclone"`
+	RestoreTargets     []RestoreTarget    `yaml:"restore_targets"`
+	Replication        []ReplicationJob   `yaml:"replication"`
+	Catalog            CatalogConfig      `yaml:"catalog"`
 	EncryptionKey      string             `yaml:"encryption_key"`
+	WorkDir            string             `yaml:"work_dir"` // scratch space: pg_basebackup tars, remote backups before upload
 	RunOnStartup       bool               `yaml:"run_on_startup"`
 	RunRemoteOnStartup bool               `yaml:"run_remote_on_startup"`
 }
@@ -21,6 +31,24 @@ type Server struct {
 	Addr string `yaml:"addr"`
 }
 
+// SchedulerConfig holds the defaults applied to every scheduled job
+type SchedulerConfig struct {
+	Overlap       string      `yaml:"overlap"`        // "skip" (default) or "queue" when a job is still running
+	MaxConcurrent int         `yaml:"max_concurrent"` // 0 = unlimited
+	Jitter        int         `yaml:"jitter"`         // max random delay in seconds before remote jobs
+	CatchUp       bool        `yaml:"catch_up"`       // run missed jobs on startup
+	Retry         RetryConfig `yaml:"retry"`
+}
+
+// RetryConfig is the job level retry policy (rclone low-level retries are separate)
+type RetryConfig struct {
+	MaxAttempts  int      `yaml:"max_attempts"`  // total attempts, 0 or 1 = no retry
+	InitialDelay int      `yaml:"initial_delay"` // seconds, default 30
+	MaxDelay     int      `yaml:"max_delay"`     // seconds, default 1800
+	Multiplier   float64  `yaml:"multiplier"`    // default 2
+	RetryOn      []string `yaml:"retry_on"`      // network, timeout, dump, all (default network, timeout)
+}
+
 type DatabaseConfig struct {
 	Host     string `yaml:"host"`
 	Port     int    `yaml:"port"`
@@ -29,6 +57,219 @@ type DatabaseConfig struct {
 	Name     string `yaml:"name"`
 }
 
+// WALConfig configures continuous archiving, used by "pgopher wal-push" (archive_command)
+// and "pgopher wal-fetch" (restore_command)
+type WALConfig struct {
+	Enabled   bool     `yaml:"enabled"`
+	Local     bool     `yaml:"local"`     // keep segments in Dir
+	Dir       string   `yaml:"dir"`       // local archive, default <local.dir>/wal
+	Providers []string `yaml:"providers"` // remote providers receiving the segments, in <path>/wal
+}
+
+// WALDir returns the local WAL archive
+func (c *Config) WALDir() string {
+	if c.WAL.Dir != "" {
+		return c.WAL.Dir
+	}
+	return filepath.Join(c.LocalBackup.Dir, "wal")
+}
+
+// CatalogConfig configures the catalog index, the last listing of every provider with the
+// backup IDs read from their manifests
+type CatalogConfig struct {
+	Index string `yaml:"index"` // index file, default <local.dir>/.catalog.json
+	TTL   int    `yaml:"ttl"`   // minutes a provider listing is reused, default 60, -1 = always list
+}
+
+// CatalogIndex returns the catalog index file
+func (c *Config) CatalogIndex() string {
+	if c.Catalog.Index != "" {
+		return c.Catalog.Index
+	}
+	return filepath.Join(c.LocalBackup.Dir, ".catalog.json")
+}
+
+// CatalogTTL returns how long a provider listing is reused, 0 when it is always listed
+func (c *Config) CatalogTTL() time.Duration {
+	switch {
+	case c.Catalog.TTL < 0:
+		return 0
+	case c.Catalog.TTL == 0:
+		return 60 * time.Minute
+	default:
+		return time.Duration(c.Catalog.TTL) * time.Minute
+	}
+}
+
+// ObjectLockConfig makes the uploads of an s3 provider immutable with S3 Object Lock,
+// the bucket must have object lock enabled
+type ObjectLockConfig struct {
+	Mode      string `yaml:"mode"`       // "governance" or "compliance", empty = no retention
+	Days      int    `yaml:"days"`       // retain-until date = upload time + days
+	LegalHold bool   `yaml:"legal_hold"` // locked until the hold is removed, independent of the retention
+}
+
+const (
+	ObjectLockGovernance = "governance"
+	ObjectLockCompliance = "compliance"
+)
+
+// Enabled reports whether uploads get a retention or a legal hold
+func (o ObjectLockConfig) Enabled() bool {
+	return o.Mode != "" || o.LegalHold
+}
+
+// RcloneSettings holds the rclone settings of the process, its tuning is the default of
+// every provider
+type RcloneSettings struct {
+	RcloneTuning `yaml:",inline"`
+	LogLevel     string `yaml:"log_level"` // rclone logging is process wide: error, notice (default), info, debug
+}
+
+// RcloneTuning are the transfer settings of a provider, applied to its rclone operations only.
+// Backend options (storage_class, server_side_encryption, chunk_size, upload_cutoff...) go in
+// the provider config
+type RcloneTuning struct {
+	BwLimit               string `yaml:"bwlimit"`                 // "10M", "off" or a timetable "08:00,512k 19:00,10M 23:00,off"
+	Transfers             int    `yaml:"transfers"`               // parallel streams of multi-thread copies
+	BufferSize            string `yaml:"buffer_size"`             // read-ahead buffer per transfer, ex: "16M"
+	StreamingUploadCutoff string `yaml:"streaming_upload_cutoff"` // uploads above it are streamed, ex: "100M"
+	ConnectTimeout        int    `yaml:"connect_timeout"`         // seconds
+	Timeout               int    `yaml:"timeout"`                 // IO idle timeout, seconds
+	LowLevelRetries       int    `yaml:"low_level_retries"`       // retries of a single request, job retries are in retry
+}
+
+// merge returns t with the fields set in override replaced
+func (t RcloneTuning) merge(override RcloneTuning) RcloneTuning {
+	if override.BwLimit != "" {
+		t.BwLimit = override.BwLimit
+	}
+	if override.Transfers != 0 {
+		t.Transfers = override.Transfers
+	}
+	if override.BufferSize != "" {
+		t.BufferSize = override.BufferSize
+	}
+	if override.StreamingUploadCutoff != "" {
+		t.StreamingUploadCutoff = override.StreamingUploadCutoff
+	}
+	if override.ConnectTimeout != 0 {
+		t.ConnectTimeout = override.ConnectTimeout
+	}
+	if override.Timeout != 0 {
+		t.Timeout = override.Timeout
+	}
+	if override.LowLevelRetries != 0 {
+		t.LowLevelRetries = override.LowLevelRetries
+	}
+	return t
+}
+
+// HooksConfig lists the hooks run around backup and restore jobs
+type HooksConfig struct {
+	PreBackup   []HookConfig `yaml:"pre_backup"`
+	PostBackup  []HookConfig `yaml:"post_backup"`
+	OnFailure   []HookConfig `yaml:"on_failure"` // after every failed backup attempt or restore
+	PreRestore  []HookConfig `yaml:"pre_restore"`
+	PostRestore []HookConfig `yaml:"post_restore"`
+}
+
+// HookConfig is a shell command or SQL statements, job details are exported as PGOPHER_* env vars
+type HookConfig struct {
+	Name    string   `yaml:"name"`
+	Command string   `yaml:"command"`  // run with sh -c
+	SQL     string   `yaml:"sql"`      // executed in the job database
+	Timeout int      `yaml:"timeout"`  // seconds, default 60
+	OnError string   `yaml:"on_error"` // abort (default for pre hooks) or continue
+	Only    []string `yaml:"only"`     // job names ("local", provider names, "restore"), empty = all
+}
+
+func (h HooksConfig) IsEnabled() bool {
+	return len(h.PreBackup) > 0 || len(h.PostBackup) > 0 || len(h.OnFailure) > 0 ||
+		len(h.PreRestore) > 0 || len(h.PostRestore) > 0
+}
+
+// RestoreConfig holds the defaults of the restore command
+type RestoreConfig struct {
+	Snapshot     bool              `yaml:"snapshot"`       // back up the target database before restoring into it
+	SnapshotKeep int               `yaml:"snapshot_keep"`  // pre-restore snapshots kept per database, default 3
+	Swap         bool              `yaml:"swap"`           // blue/green: restore into a new database and rename it over the target
+	KeepOldHours int               `yaml:"keep_old_hours"` // hours the replaced database is kept, default 24
+	Drain        DrainConfig       `yaml:"drain"`
+	PostRestore  PostRestoreConfig `yaml:"post_restore"`
+}
+
+// PostRestoreConfig lists the maintenance steps run after pg_restore, in this order
+type PostRestoreConfig struct {
+	Analyze         string        `yaml:"analyze"`          // "" (none), "analyze" or "vacuum_analyze"
+	ResetSequences  bool          `yaml:"reset_sequences"`  // set owned sequences to the max of their column
+	RefreshMatViews bool          `yaml:"refresh_matviews"` // REFRESH MATERIALIZED VIEW for every view
+	Owner           string        `yaml:"owner"`            // role owning schemas, tables, views and sequences
+	Grants          []GrantConfig `yaml:"grants"`           // dumps are taken with --no-owner --no-acl
+	SQLFiles        []string      `yaml:"sql_files"`        // user supplied SQL, run last
+}
+
+type GrantConfig struct {
+	Role       string   `yaml:"role"`
+	Privileges string   `yaml:"privileges"` // on all tables, ex: "SELECT" or "SELECT, INSERT, UPDATE, DELETE"
+	Sequences  string   `yaml:"sequences"`  // on all sequences, ex: "USAGE, SELECT", empty = none
+	Schemas    []string `yaml:"schemas"`    // default ["public"]
+}
+
+func (p PostRestoreConfig) IsEnabled() bool {
+	return p.Analyze != "" || p.ResetSequences || p.RefreshMatViews || p.Owner != "" ||
+		len(p.Grants) > 0 || len(p.SQLFiles) > 0
+}
+
+// DrainConfig clears the sessions of the target database before an in-place restore
+type DrainConfig struct {
+	Enabled   bool     `yaml:"enabled"`    // revoke CONNECT and wait for the sessions to end
+	Terminate bool     `yaml:"terminate"`  // pg_terminate_backend the sessions instead of only waiting
+	AllowApps []string `yaml:"allow_apps"` // application names never terminated nor waited for
+	Timeout   int      `yaml:"timeout"`    // seconds, default 60
+}
+
+func (d DrainConfig) TimeoutDuration() time.Duration {
+	if d.Timeout <= 0 {
+		return 60 * time.Second
+	}
+	return time.Duration(d.Timeout) * time.Second
+}
+
+func (r RestoreConfig) KeepSnapshots() int {
+	if r.SnapshotKeep <= 0 {
+		return 3
+	}
+	return r.SnapshotKeep
+}
+
+func (r RestoreConfig) KeepOld() time.Duration {
+	if r.KeepOldHours <= 0 {
+		return 24 * time.Hour
+	}
+	return time.Duration(r.KeepOldHours) * time.Hour
+}
+
+// RestoreTarget is a named database/server to restore into; empty fields
+// are inherited from the source database
+type RestoreTarget struct {
+	Name     string         `yaml:"name"`
+	Database DatabaseConfig `yaml:"database"`
+}
+
+// ReplicationJob copies on a schedule the backups of a destination ("local" or a provider)
+// missing from another provider
+type ReplicationJob struct {
+	Name     string       `yaml:"name"`
+	From     string       `yaml:"from"` // "local" or a provider name
+	To       string       `yaml:"to"`   // provider name
+	Schedule []string     `yaml:"schedule"`
+	MaxAge   int          `yaml:"max_age"` // days, only newer backups are copied, 0 = all
+	Timeout  int          `yaml:"timeout"` // seconds, default 3600
+	Overlap  string       `yaml:"overlap"` // overrides scheduler.overlap
+	Retry    *RetryConfig `yaml:"retry"`   // overrides scheduler.retry
+}
+
 type RetentionConfig struct {
 	RetentionDays *int `yaml:"retention_days"`
 	MaxBackups    *int `yaml:"max_backups"`
@@ -38,17 +279,65 @@ type LocalBackupConfig struct {
 	Schedule  []string        `yaml:"schedule"`
 	Retention RetentionConfig `yaml:"retention"`
 	Enabled   bool            `yaml:"enabled"`
+	Heartbeat HeartbeatConfig `yaml:"heartbeat"`
+	Method    string          `yaml:"method"`  // "logical" (pg_dump, default) or "physical" (pg_basebackup)
+	Overlap   string          `yaml:"overlap"` // overrides scheduler.overlap
+	Jitter    *int            `yaml:"jitter"`  // overrides scheduler.jitter (seconds)
+	Retry     *RetryConfig    `yaml:"retry"`   // overrides scheduler.retry
+	Timeout   int             `yaml:"timeout"` // seconds, default 1800 (logical) or 86400 (physical)
+}
+
+// TimeoutDuration is the timeout of a local backup, a physical one copies the whole cluster
+func (l LocalBackupConfig) TimeoutDuration() time.Duration {
+	switch {
+	case l.Timeout > 0:
+		return time.Duration(l.Timeout) * time.Second
+	case IsPhysical(l.Method):
+		return 24 * time.Hour
+	default:
+		return 30 * time.Minute
+	}
+}
+
+const (
+	MethodLogical  = "logical"
+	MethodPhysical = "physical"
+)
+
+// IsPhysical reports whether method selects pg_basebackup, empty means logical
+func IsPhysical(method string) bool {
+	return method == MethodPhysical
+}
+
+// HeartbeatConfig configures dead-man's-switch pings sent around each job
+// (healthchecks.io or Uptime Kuma push monitors).
+type HeartbeatConfig struct {
+	Kind       string `yaml:"kind"` // "healthchecks" (default) or "uptime_kuma"
+	URL        string `yaml:"url"`
+	StartURL   string `yaml:"start_url"`   // overrides the derived start URL
+	SuccessURL string `yaml:"success_url"` // overrides the derived success URL
+	FailURL    string `yaml:"fail_url"`    // overrides the derived fail URL
+	Timeout    int    `yaml:"timeout"`     // segundos
 }
 
 type RemoteProvider struct {
-	Name        string            `yaml:"name"`
-	Type        string            `yaml:"type"` // "s3", "gdrive", "dropbox"
-	Enabled     bool              `yaml:"enabled"`
-	Schedule    []string          `yaml:"schedule"`
-	Path        string            `yaml:"path"`
-	MaxVersions int               `yaml:"maxVersions"` // 0 = sem versionamento
-	Timeout     int               `yaml:"timeout"`     // segundos
-	Config      map[string]string `yaml:"config"`
+	Name         string            `yaml:"name"`
+	Type         string            `yaml:"type"` // "s3", "gdrive", "dropbox"
+	Enabled      bool              `yaml:"enabled"`
+	Schedule     []string          `yaml:"schedule"`
+	Path         string            `yaml:"path"`
+	MaxVersions  int               `yaml:"maxVersions"` // 0 = sem versionamento
+	Timeout      int               `yaml:"timeout"`     // segundos
+	Config       map[string]string `yaml:"config"`
+	Remote       string            `yaml:"remote"`        // named remote of an rclone.conf (ex: "crypt-backups"), replaces type/config
+	RcloneConfig string            `yaml:"rclone_config"` // rclone.conf holding remote, default rclone's own config path
+	Heartbeat    HeartbeatConfig   `yaml:"heartbeat"`
+	Rclone       RcloneTuning      `yaml:"rclone"` // overrides rclone (transfer settings, backend options go in config)
+	ObjectLock   ObjectLockConfig  `yaml:"object_lock"`
+	Method       string            `yaml:"method"`  // "logical" (pg_dump, default) or "physical" (pg_basebackup)
+	Overlap      string            `yaml:"overlap"` // overrides scheduler.overlap
+	Jitter       *int              `yaml:"jitter"`  // overrides scheduler.jitter (seconds)
+	Retry        *RetryConfig      `yaml:"retry"`   // overrides scheduler.retry
 }
 
 type NotificationConfig struct {
@@ -68,6 +357,15 @@ type NotificationConfig struct {
 
 	TelegramBotToken string `yaml:"telegram_bot_token"`
 	TelegramChatID   string `yaml:"telegram_chat_id"`
+
+	Digest DigestConfig `yaml:"digest"`
+}
+
+// DigestConfig configures the periodic summary report sent by the daemon
+type DigestConfig struct {
+	Enabled         bool   `yaml:"enabled"`
+	Schedule        string `yaml:"schedule"`          // cron, ex: "0 8 * * *" (daily) or "0 8 * * 1" (weekly)
+	StaleAfterHours int    `yaml:"stale_after_hours"` // warn when newest backup is older, default 26
 }
 
 func (c *Config) GetLocation() (*time.Location, error) {
@@ -93,6 +391,40 @@ func (c *DatabaseConfig) ConnectionString() string {
 	)
 }
 
+// WithDefaults fills the empty fields with the values of the given config
+func (c DatabaseConfig) WithDefaults(defaults DatabaseConfig) DatabaseConfig {
+	if c.Host == "" {
+		c.Host = defaults.Host
+	}
+	if c.Port == 0 {
+		c.Port = defaults.Port
+	}
+	if c.Username == "" {
+		c.Username = defaults.Username
+		if c.Password == "" {
+			c.Password = defaults.Password
+		}
+	}
+	if c.Name == "" {
+		c.Name = defaults.Name
+	}
+	return c
+}
+
+// SameAs reports whether both configs point to the same database
+func (c DatabaseConfig) SameAs(other DatabaseConfig) bool {
+	return c.Host == other.Host && c.Port == other.Port && c.Name == other.Name
+}
+
+func (c *Config) FindRestoreTarget(name string) (*RestoreTarget, error) {
+	for i := range c.RestoreTargets {
+		if c.RestoreTargets[i].Name == name {
+			return &c.RestoreTargets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("restore target %s not found", name)
+}
+
 func (c *Config) IsEncryptEnabled() bool {
 	return c.EncryptionKey != ""
 }
@@ -113,6 +445,52 @@ func (c *NotificationConfig) IsMails() bool {
 	return len(c.Emails) > 0
 }
 
+func (r *RetryConfig) Attempts() int {
+	if r.MaxAttempts < 1 {
+		return 1
+	}
+	return r.MaxAttempts
+}
+
+func (r *RetryConfig) Initial() time.Duration {
+	if r.InitialDelay <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(r.InitialDelay) * time.Second
+}
+
+func (r *RetryConfig) Max() time.Duration {
+	if r.MaxDelay <= 0 {
+		return 30 * time.Minute
+	}
+	return time.Duration(r.MaxDelay) * time.Second
+}
+
+func (r *RetryConfig) Factor() float64 {
+	if r.Multiplier < 1 {
+		return 2
+	}
+	return r.Multiplier
+}
+
+func (r *RetryConfig) Classes() []string {
+	if len(r.RetryOn) == 0 {
+		return []string{"network", "timeout"}
+	}
+	return r.RetryOn
+}
+
+func (d *DigestConfig) StaleAfter() time.Duration {
+	if d.StaleAfterHours <= 0 {
+		return 26 * time.Hour
+	}
+	return time.Duration(d.StaleAfterHours) * time.Hour
+}
+
+func (h *HeartbeatConfig) IsEnabled() bool {
+	return h.URL != "" || h.StartURL != "" || h.SuccessURL != "" || h.FailURL != ""
+}
+
 func (r *RetentionConfig) HasRetentionDays() bool {
 	return r.RetentionDays != nil
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/BrunoTulio/pgopher/internal/utils"
@@ -119,6 +120,7 @@ func LoadFromYAML(path string) (*Config, error) {
 	}
 
 	loadEnvOverrides(cfg)
+	applyRcloneDefaults(cfg)
 
 	return cfg, cfg.Validate()
 }
@@ -134,6 +136,9 @@ func loadEnvOverrides(cfg *Config) {
 	if runRemoteOnStartup, ok := boolLookup("RUN_REMOTE_ON_STARTUP"); ok {
 		cfg.RunRemoteOnStartup = runRemoteOnStartup
 	}
+	if workDir, ok := stringLookup("WORK_DIR"); ok {
+		cfg.WorkDir = workDir
+	}
 	if encryptionKey, ok := stringLookup("BACKUP_ENCRYPTION_KEY"); ok {
 		cfg.EncryptionKey = encryptionKey
 	}
@@ -161,7 +166,7 @@ func loadEnvOverrides(cfg *Config) {
 	if localBackupDir, ok := stringLookup("BACKUP_DIR"); ok {
 		cfg.LocalBackup.Dir = localBackupDir
 	}
-	if localBackupSchedule, ok := stringsLookup("BACKUP_SCHEDULE"); ok {
+	if localBackupSchedule, ok := schedulesLookup("BACKUP_SCHEDULE"); ok {
 		cfg.LocalBackup.Schedule = localBackupSchedule
 	}
 	if localBackupEnabled, ok := boolLookup("BACKUP_ENABLED"); ok {
@@ -173,6 +178,18 @@ func loadEnvOverrides(cfg *Config) {
 	if localBackupLimit, ok := intLookup("BACKUP_LIMIT"); ok {
 		cfg.LocalBackup.Retention.MaxBackups = &localBackupLimit
 	}
+	if localHeartbeatURL, ok := stringLookup("BACKUP_HEARTBEAT_URL"); ok {
+		cfg.LocalBackup.Heartbeat.URL = localHeartbeatURL
+	}
+	if localHeartbeatKind, ok := stringLookup("BACKUP_HEARTBEAT_KIND"); ok {
+		cfg.LocalBackup.Heartbeat.Kind = localHeartbeatKind
+	}
+	if localMethod, ok := stringLookup("BACKUP_METHOD"); ok {
+		cfg.LocalBackup.Method = localMethod
+	}
+	if localTimeout, ok := intLookup("BACKUP_TIMEOUT"); ok {
+		cfg.LocalBackup.Timeout = localTimeout
+	}
 
 	if notificationSuccessEnabled, ok := boolLookup("NOTIFICATION_SUCCESS_ENABLED"); ok {
 		cfg.Notification.SuccessEnabled = notificationSuccessEnabled
@@ -213,6 +230,105 @@ func loadEnvOverrides(cfg *Config) {
 	if telegramChatId, ok := stringLookup("TELEGRAM_CHAT_ID"); ok {
 		cfg.Notification.TelegramChatID = telegramChatId
 	}
+	if schedulerOverlap, ok := stringLookup("SCHEDULER_OVERLAP"); ok {
+		cfg.Scheduler.Overlap = schedulerOverlap
+	}
+	if schedulerMaxConcurrent, ok := intLookup("SCHEDULER_MAX_CONCURRENT"); ok {
+		cfg.Scheduler.MaxConcurrent = schedulerMaxConcurrent
+	}
+	if schedulerJitter, ok := intLookup("SCHEDULER_JITTER"); ok {
+		cfg.Scheduler.Jitter = schedulerJitter
+	}
+	if schedulerCatchUp, ok := boolLookup("SCHEDULER_CATCH_UP"); ok {
+		cfg.Scheduler.CatchUp = schedulerCatchUp
+	}
+	if retryMaxAttempts, ok := intLookup("RETRY_MAX_ATTEMPTS"); ok {
+		cfg.Scheduler.Retry.MaxAttempts = retryMaxAttempts
+	}
+	if retryInitialDelay, ok := intLookup("RETRY_INITIAL_DELAY"); ok {
+		cfg.Scheduler.Retry.InitialDelay = retryInitialDelay
+	}
+	if retryMaxDelay, ok := intLookup("RETRY_MAX_DELAY"); ok {
+		cfg.Scheduler.Retry.MaxDelay = retryMaxDelay
+	}
+	if retryOn, ok := stringsLookup("RETRY_ON"); ok {
+		cfg.Scheduler.Retry.RetryOn = retryOn
+	}
+
+	if restoreSnapshot, ok := boolLookup("RESTORE_SNAPSHOT"); ok {
+		cfg.Restore.Snapshot = restoreSnapshot
+	}
+	if restoreSnapshotKeep, ok := intLookup("RESTORE_SNAPSHOT_KEEP"); ok {
+		cfg.Restore.SnapshotKeep = restoreSnapshotKeep
+	}
+	if restoreSwap, ok := boolLookup("RESTORE_SWAP"); ok {
+		cfg.Restore.Swap = restoreSwap
+	}
+	if restoreKeepOldHours, ok := intLookup("RESTORE_KEEP_OLD_HOURS"); ok {
+		cfg.Restore.KeepOldHours = restoreKeepOldHours
+	}
+	if restoreDrain, ok := boolLookup("RESTORE_DRAIN"); ok {
+		cfg.Restore.Drain.Enabled = restoreDrain
+	}
+	if restoreTerminate, ok := boolLookup("RESTORE_TERMINATE"); ok {
+		cfg.Restore.Drain.Terminate = restoreTerminate
+	}
+	if restoreAllowApps, ok := stringsLookup("RESTORE_ALLOW_APPS"); ok {
+		cfg.Restore.Drain.AllowApps = restoreAllowApps
+	}
+	if restoreDrainTimeout, ok := intLookup("RESTORE_DRAIN_TIMEOUT"); ok {
+		cfg.Restore.Drain.Timeout = restoreDrainTimeout
+	}
+	if restoreAnalyze, ok := stringLookup("RESTORE_ANALYZE"); ok {
+		cfg.Restore.PostRestore.Analyze = restoreAnalyze
+	}
+	if restoreResetSequences, ok := boolLookup("RESTORE_RESET_SEQUENCES"); ok {
+		cfg.Restore.PostRestore.ResetSequences = restoreResetSequences
+	}
+	if restoreRefreshMatViews, ok := boolLookup("RESTORE_REFRESH_MATVIEWS"); ok {
+		cfg.Restore.PostRestore.RefreshMatViews = restoreRefreshMatViews
+	}
+	if restoreOwner, ok := stringLookup("RESTORE_OWNER"); ok {
+		cfg.Restore.PostRestore.Owner = restoreOwner
+	}
+	if restoreSQLFiles, ok := stringsLookup("RESTORE_SQL_FILES"); ok {
+		cfg.Restore.PostRestore.SQLFiles = restoreSQLFiles
+	}
+
+	if walEnabled, ok := boolLookup("WAL_ENABLED"); ok {
+		cfg.WAL.Enabled = walEnabled
+	}
+	if walLocal, ok := boolLookup("WAL_LOCAL"); ok {
+		cfg.WAL.Local = walLocal
+	}
+	if walDir, ok := stringLookup("WAL_DIR"); ok {
+		cfg.WAL.Dir = walDir
+	}
+	if walProviders, ok := stringsLookup("WAL_PROVIDERS"); ok {
+		cfg.WAL.Providers = walProviders
+	}
+
+	if digestEnabled, ok := boolLookup("DIGEST_ENABLED"); ok {
+		cfg.Notification.Digest.Enabled = digestEnabled
+	}
+	if digestSchedule, ok := stringLookup("DIGEST_SCHEDULE"); ok {
+		cfg.Notification.Digest.Schedule = digestSchedule
+	}
+	if digestStaleAfterHours, ok := intLookup("DIGEST_STALE_AFTER_HOURS"); ok {
+		cfg.Notification.Digest.StaleAfterHours = digestStaleAfterHours
+	}
+
+	if catalogIndex, ok := stringLookup("CATALOG_INDEX"); ok {
+		cfg.Catalog.Index = catalogIndex
+	}
+	if catalogTTL, ok := intLookup("CATALOG_TTL"); ok {
+		cfg.Catalog.TTL = catalogTTL
+	}
+
+	overrideRcloneTuning("RCLONE_", &cfg.Rclone.RcloneTuning)
+	if rcloneLogLevel, ok := stringLookup("RCLONE_LOG_LEVEL"); ok {
+		cfg.Rclone.LogLevel = rcloneLogLevel
+	}
 
 	cfg.RemoteProviders = overrideProviders(cfg.RemoteProviders)
 
@@ -225,12 +341,53 @@ func LoadFromEnv() (*Config, error) {
 		RunOnStartup:       boolOrEmpty("RUN_ON_STARTUP", false),
 		RunRemoteOnStartup: boolOrEmpty("RUN_REMOTE_ON_STARTUP", false),
 		EncryptionKey:      stringOrEmpty("BACKUP_ENCRYPTION_KEY", ""),
+		WorkDir:            stringOrEmpty("WORK_DIR", ""),
 	}
 
 	cfg.Server = Server{
 		Addr: stringOrEmpty("SERVER_ADDR", ":8080"),
 	}
 
+	cfg.Scheduler = SchedulerConfig{
+		Overlap:       stringOrEmpty("SCHEDULER_OVERLAP", "skip"),
+		MaxConcurrent: intOrEmpty("SCHEDULER_MAX_CONCURRENT", 0),
+		Jitter:        intOrEmpty("SCHEDULER_JITTER", 0),
+		CatchUp:       boolOrEmpty("SCHEDULER_CATCH_UP", false),
+		Retry: RetryConfig{
+			MaxAttempts:  intOrEmpty("RETRY_MAX_ATTEMPTS", 1),
+			InitialDelay: intOrEmpty("RETRY_INITIAL_DELAY", 30),
+			MaxDelay:     intOrEmpty("RETRY_MAX_DELAY", 1800),
+			RetryOn:      stringsOrEmpty("RETRY_ON", []string{}),
+		},
+	}
+
+	cfg.Restore = RestoreConfig{
+		Snapshot:     boolOrEmpty("RESTORE_SNAPSHOT", false),
+		SnapshotKeep: intOrEmpty("RESTORE_SNAPSHOT_KEEP", 3),
+		Swap:         boolOrEmpty("RESTORE_SWAP", false),
+		KeepOldHours: intOrEmpty("RESTORE_KEEP_OLD_HOURS", 24),
+		Drain: DrainConfig{
+			Enabled:   boolOrEmpty("RESTORE_DRAIN", false),
+			Terminate: boolOrEmpty("RESTORE_TERMINATE", false),
+			AllowApps: stringsOrEmpty("RESTORE_ALLOW_APPS", []string{}),
+			Timeout:   intOrEmpty("RESTORE_DRAIN_TIMEOUT", 60),
+		},
+		PostRestore: PostRestoreConfig{
+			Analyze:         stringOrEmpty("RESTORE_ANALYZE", ""),
+			ResetSequences:  boolOrEmpty("RESTORE_RESET_SEQUENCES", false),
+			RefreshMatViews: boolOrEmpty("RESTORE_REFRESH_MATVIEWS", false),
+			Owner:           stringOrEmpty("RESTORE_OWNER", ""),
+			SQLFiles:        stringsOrEmpty("RESTORE_SQL_FILES", []string{}),
+		},
+	}
+
+	cfg.WAL = WALConfig{
+		Enabled:   boolOrEmpty("WAL_ENABLED", false),
+		Local:     boolOrEmpty("WAL_LOCAL", true),
+		Dir:       stringOrEmpty("WAL_DIR", ""),
+		Providers: stringsOrEmpty("WAL_PROVIDERS", []string{}),
+	}
+
 	cfg.Database = DatabaseConfig{
 		Host:     mustString("DATABASE_HOST"),
 		Port:     intOrEmpty("DATABASE_PORT", 5432),
@@ -240,9 +397,12 @@ func LoadFromEnv() (*Config, error) {
 	}
 
 	cfg.LocalBackup = LocalBackupConfig{
-		Dir:      stringOrEmpty("BACKUP_DIR", "/backups"),
-		Schedule: stringsOrEmpty("BACKUP_SCHEDULE", []string{}),
-		Enabled:  true,
+		Dir:       stringOrEmpty("BACKUP_DIR", "/backups"),
+		Schedule:  schedulesOrEmpty("BACKUP_SCHEDULE", []string{}),
+		Enabled:   true,
+		Heartbeat: loadHeartbeat("BACKUP_"),
+		Method:    stringOrEmpty("BACKUP_METHOD", ""),
+		Timeout:   intOrEmpty("BACKUP_TIMEOUT", 0),
 	}
 	if days := stringOrEmpty("RETENTION_DAYS", ""); days != "" {
 		if d, err := strconv.Atoi(days); err == nil {
@@ -255,7 +415,18 @@ func LoadFromEnv() (*Config, error) {
 		}
 	}
 
+	cfg.Catalog = CatalogConfig{
+		Index: stringOrEmpty("CATALOG_INDEX", ""),
+		TTL:   intOrEmpty("CATALOG_TTL", 60),
+	}
+
+	cfg.Rclone = RcloneSettings{
+		RcloneTuning: loadRcloneTuning("RCLONE_"),
+		LogLevel:     stringOrEmpty("RCLONE_LOG_LEVEL", ""),
+	}
+
 	cfg.RemoteProviders = loadProviders()
+	applyRcloneDefaults(cfg)
 
 	cfg.Notification = NotificationConfig{
 		SuccessEnabled:    boolOrEmpty("NOTIFICATION_SUCCESS_ENABLED", false),
@@ -271,6 +442,11 @@ func LoadFromEnv() (*Config, error) {
 		DiscordWebhookURL: stringOrEmpty("DISCORD_WEBHOOK_URL", ""),
 		TelegramBotToken:  stringOrEmpty("TELEGRAM_BOT_TOKEN", ""),
 		TelegramChatID:    stringOrEmpty("TELEGRAM_CHAT_ID", ""),
+		Digest: DigestConfig{
+			Enabled:         boolOrEmpty("DIGEST_ENABLED", false),
+			Schedule:        stringOrEmpty("DIGEST_SCHEDULE", "0 8 * * *"),
+			StaleAfterHours: intOrEmpty("DIGEST_STALE_AFTER_HOURS", 26),
+		},
 	}
 
 	return cfg, cfg.Validate()
@@ -318,6 +494,24 @@ func overrideProviders(providers []RemoteProvider) []RemoteProvider {
 
 	}
 
+	// other rclone backends: REMOTE_<NAME>_<KEY> overrides the keys present in the YAML,
+	// ex: REMOTE_NAS_PASS for a sftp provider named "nas"
+	for i := range providers {
+		if _, ok := providerSchemas[providers[i].Name]; ok {
+			continue
+		}
+		if providers[i].Config == nil {
+			providers[i].Config = map[string]string{}
+		}
+
+		configMap := make(map[string]ConfigField, len(providers[i].Config))
+		for key := range providers[i].Config {
+			configMap[strings.ToUpper(key)] = ConfigField{Name: key}
+		}
+		prefix := "REMOTE_" + strings.ToUpper(strings.ReplaceAll(providers[i].Name, "-", "_")) + "_"
+		loadEnvOverride(prefix, configMap, &providers[i])
+	}
+
 	return providers
 }
 
@@ -344,7 +538,7 @@ func loadEnvOverride(prefix string, configMap map[string]ConfigField, remote *Re
 	if providerPath, ok := stringLookup(prefix + "PATH"); ok {
 		remote.Path = providerPath
 	}
-	if providerSchedules, ok := stringsLookup(prefix + "SCHEDULE"); ok {
+	if providerSchedules, ok := schedulesLookup(prefix + "SCHEDULE"); ok {
 		remote.Schedule = providerSchedules
 	}
 	if providerMaxVersions, ok := intLookup(prefix + "MAX_VERSIONS"); ok {
@@ -353,6 +547,31 @@ func loadEnvOverride(prefix string, configMap map[string]ConfigField, remote *Re
 	if providerTimeout, ok := intLookup(prefix + "TIMEOUT"); ok {
 		remote.Timeout = providerTimeout
 	}
+	if providerHeartbeatURL, ok := stringLookup(prefix + "HEARTBEAT_URL"); ok {
+		remote.Heartbeat.URL = providerHeartbeatURL
+	}
+	if providerHeartbeatKind, ok := stringLookup(prefix + "HEARTBEAT_KIND"); ok {
+		remote.Heartbeat.Kind = providerHeartbeatKind
+	}
+	if providerMethod, ok := stringLookup(prefix + "METHOD"); ok {
+		remote.Method = providerMethod
+	}
+	if providerRemote, ok := stringLookup(prefix + "REMOTE"); ok {
+		remote.Remote = providerRemote
+	}
+	if providerRcloneConfig, ok := stringLookup(prefix + "RCLONE_CONFIG"); ok {
+		remote.RcloneConfig = providerRcloneConfig
+	}
+	overrideRcloneTuning(prefix+"RCLONE_", &remote.Rclone)
+	if objectLockMode, ok := stringLookup(prefix + "OBJECT_LOCK_MODE"); ok {
+		remote.ObjectLock.Mode = objectLockMode
+	}
+	if objectLockDays, ok := intLookup(prefix + "OBJECT_LOCK_DAYS"); ok {
+		remote.ObjectLock.Days = objectLockDays
+	}
+	if objectLockLegalHold, ok := boolLookup(prefix + "OBJECT_LOCK_LEGAL_HOLD"); ok {
+		remote.ObjectLock.LegalHold = objectLockLegalHold
+	}
 
 	for envKey, configKey := range configMap {
 		if value, ok := stringLookup(prefix + envKey); ok {
@@ -375,13 +594,23 @@ func loadS3Provider() *RemoteProvider {
 	}
 
 	return &RemoteProvider{
-		Name:        "s3",
-		Type:        "s3",
-		Enabled:     true,
-		Path:        stringOrEmpty(prefix+"PATH", ""),
-		Schedule:    stringsOrEmpty(prefix+"SCHEDULE", []string{}),
-		MaxVersions: intOrEmpty(prefix+"MAX_VERSIONS", 0),
-		Timeout:     intOrEmpty(prefix+"TIMEOUT", 7200),
+		Name:         "s3",
+		Type:         "s3",
+		Enabled:      true,
+		Path:         stringOrEmpty(prefix+"PATH", ""),
+		Schedule:     schedulesOrEmpty(prefix+"SCHEDULE", []string{}),
+		MaxVersions:  intOrEmpty(prefix+"MAX_VERSIONS", 0),
+		Timeout:      intOrEmpty(prefix+"TIMEOUT", 7200),
+		Heartbeat:    loadHeartbeat(prefix),
+		Method:       stringOrEmpty(prefix+"METHOD", ""),
+		Remote:       stringOrEmpty(prefix+"REMOTE", ""),
+		RcloneConfig: stringOrEmpty(prefix+"RCLONE_CONFIG", ""),
+		Rclone:       loadRcloneTuning(prefix + "RCLONE_"),
+		ObjectLock: ObjectLockConfig{
+			Mode:      stringOrEmpty(prefix+"OBJECT_LOCK_MODE", ""),
+			Days:      intOrEmpty(prefix+"OBJECT_LOCK_DAYS", 0),
+			LegalHold: boolOrEmpty(prefix+"OBJECT_LOCK_LEGAL_HOLD", false),
+		},
 		Config: map[string]string{
 			"provider":          stringOrEmpty(prefix+"PROVIDER", "AWS"),
 			"access_key_id":     stringOrEmpty(prefix+"ACCESS_KEY_ID", ""),
@@ -403,13 +632,18 @@ func loadGDriveProvider() *RemoteProvider {
 	}
 	tokenBase64 := stringOrEmpty(prefix+"TOKEN", "")
 	return &RemoteProvider{
-		Name:        "gdrive",
-		Type:        "drive",
-		Enabled:     true,
-		Path:        stringOrEmpty(prefix+"PATH", ""),
-		Schedule:    stringsOrEmpty(prefix+"SCHEDULE", []string{}),
-		MaxVersions: intOrEmpty(prefix+"MAX_VERSIONS", 0),
-		Timeout:     intOrEmpty(prefix+"TIMEOUT", 7200),
+		Name:         "gdrive",
+		Type:         "drive",
+		Enabled:      true,
+		Path:         stringOrEmpty(prefix+"PATH", ""),
+		Schedule:     schedulesOrEmpty(prefix+"SCHEDULE", []string{}),
+		MaxVersions:  intOrEmpty(prefix+"MAX_VERSIONS", 0),
+		Timeout:      intOrEmpty(prefix+"TIMEOUT", 7200),
+		Heartbeat:    loadHeartbeat(prefix),
+		Method:       stringOrEmpty(prefix+"METHOD", ""),
+		Remote:       stringOrEmpty(prefix+"REMOTE", ""),
+		RcloneConfig: stringOrEmpty(prefix+"RCLONE_CONFIG", ""),
+		Rclone:       loadRcloneTuning(prefix + "RCLONE_"),
 		Config: map[string]string{
 			"token": utils.DecodeBase64(tokenBase64),
 			"scope": stringOrEmpty(prefix+"SCOPE", "drive"),
@@ -427,13 +661,18 @@ func loadDropboxProvider() *RemoteProvider {
 	tokenBase64 := stringOrEmpty(prefix+"TOKEN", "")
 
 	return &RemoteProvider{
-		Name:        "dropbox",
-		Type:        "dropbox",
-		Enabled:     true,
-		Path:        stringOrEmpty(prefix+"PATH", ""),
-		Schedule:    stringsOrEmpty(prefix+"SCHEDULE", []string{}),
-		MaxVersions: intOrEmpty(prefix+"MAX_VERSIONS", 0),
-		Timeout:     intOrEmpty(prefix+"TIMEOUT", 7200),
+		Name:         "dropbox",
+		Type:         "dropbox",
+		Enabled:      true,
+		Path:         stringOrEmpty(prefix+"PATH", ""),
+		Schedule:     schedulesOrEmpty(prefix+"SCHEDULE", []string{}),
+		MaxVersions:  intOrEmpty(prefix+"MAX_VERSIONS", 0),
+		Timeout:      intOrEmpty(prefix+"TIMEOUT", 7200),
+		Heartbeat:    loadHeartbeat(prefix),
+		Method:       stringOrEmpty(prefix+"METHOD", ""),
+		Remote:       stringOrEmpty(prefix+"REMOTE", ""),
+		RcloneConfig: stringOrEmpty(prefix+"RCLONE_CONFIG", ""),
+		Rclone:       loadRcloneTuning(prefix + "RCLONE_"),
 		Config: map[string]string{
 			"token": utils.DecodeBase64(tokenBase64),
 		},
@@ -448,13 +687,18 @@ func loadMegaProvider() *RemoteProvider {
 	}
 
 	return &RemoteProvider{
-		Name:        "mega",
-		Type:        "mega",
-		Enabled:     true,
-		Path:        stringOrEmpty(prefix+"PATH", ""),
-		Schedule:    stringsOrEmpty(prefix+"SCHEDULE", []string{}),
-		MaxVersions: intOrEmpty(prefix+"MAX_VERSIONS", 0),
-		Timeout:     intOrEmpty(prefix+"TIMEOUT", 7200),
+		Name:         "mega",
+		Type:         "mega",
+		Enabled:      true,
+		Path:         stringOrEmpty(prefix+"PATH", ""),
+		Schedule:     schedulesOrEmpty(prefix+"SCHEDULE", []string{}),
+		MaxVersions:  intOrEmpty(prefix+"MAX_VERSIONS", 0),
+		Timeout:      intOrEmpty(prefix+"TIMEOUT", 7200),
+		Heartbeat:    loadHeartbeat(prefix),
+		Method:       stringOrEmpty(prefix+"METHOD", ""),
+		Remote:       stringOrEmpty(prefix+"REMOTE", ""),
+		RcloneConfig: stringOrEmpty(prefix+"RCLONE_CONFIG", ""),
+		Rclone:       loadRcloneTuning(prefix + "RCLONE_"),
 		Config: map[string]string{
 			"user": stringOrEmpty(prefix+"USER", ""),
 			"pass": stringOrEmpty(prefix+"PASS", ""),
@@ -472,13 +716,18 @@ func loadGCSProvider() *RemoteProvider {
 	accountBase64 := stringOrEmpty(prefix+"SERVICE_ACCOUNT_CREDENTIALS", "")
 
 	return &RemoteProvider{
-		Name:        "gcs",
-		Type:        "google cloud storage",
-		Enabled:     true,
-		Path:        stringOrEmpty(prefix+"PATH", ""),
-		Schedule:    stringsOrEmpty(prefix+"SCHEDULE", []string{}),
-		MaxVersions: intOrEmpty(prefix+"MAX_VERSIONS", 0),
-		Timeout:     intOrEmpty(prefix+"TIMEOUT", 7200),
+		Name:         "gcs",
+		Type:         "google cloud storage",
+		Enabled:      true,
+		Path:         stringOrEmpty(prefix+"PATH", ""),
+		Schedule:     schedulesOrEmpty(prefix+"SCHEDULE", []string{}),
+		MaxVersions:  intOrEmpty(prefix+"MAX_VERSIONS", 0),
+		Timeout:      intOrEmpty(prefix+"TIMEOUT", 7200),
+		Heartbeat:    loadHeartbeat(prefix),
+		Method:       stringOrEmpty(prefix+"METHOD", ""),
+		Remote:       stringOrEmpty(prefix+"REMOTE", ""),
+		RcloneConfig: stringOrEmpty(prefix+"RCLONE_CONFIG", ""),
+		Rclone:       loadRcloneTuning(prefix + "RCLONE_"),
 		Config: map[string]string{
 			"service_account_credentials": utils.DecodeBase64(accountBase64),
 			"project_number":              stringOrEmpty(prefix+"PROJECT_NUMBER", ""),
@@ -488,3 +737,47 @@ func loadGCSProvider() *RemoteProvider {
 		},
 	}
 }
+
+func loadHeartbeat(prefix string) HeartbeatConfig {
+	return HeartbeatConfig{
+		Kind: stringOrEmpty(prefix+"HEARTBEAT_KIND", ""),
+		URL:  stringOrEmpty(prefix+"HEARTBEAT_URL", ""),
+	}
+}
+
+func loadRcloneTuning(prefix string) RcloneTuning {
+	var tuning RcloneTuning
+	overrideRcloneTuning(prefix, &tuning)
+	return tuning
+}
+
+func overrideRcloneTuning(prefix string, tuning *RcloneTuning) {
+	if bwLimit, ok := stringLookup(prefix + "BWLIMIT"); ok {
+		tuning.BwLimit = bwLimit
+	}
+	if transfers, ok := intLookup(prefix + "TRANSFERS"); ok {
+		tuning.Transfers = transfers
+	}
+	if bufferSize, ok := stringLookup(prefix + "BUFFER_SIZE"); ok {
+		tuning.BufferSize = bufferSize
+	}
+	if streamingUploadCutoff, ok := stringLookup(prefix + "STREAMING_UPLOAD_CUTOFF"); ok {
+		tuning.StreamingUploadCutoff = streamingUploadCutoff
+	}
+	if connectTimeout, ok := intLookup(prefix + "CONNECT_TIMEOUT"); ok {
+		tuning.ConnectTimeout = connectTimeout
+	}
+	if timeout, ok := intLookup(prefix + "TIMEOUT"); ok {
+		tuning.Timeout = timeout
+	}
+	if lowLevelRetries, ok := intLookup(prefix + "LOW_LEVEL_RETRIES"); ok {
+		tuning.LowLevelRetries = lowLevelRetries
+	}
+}
+
+// applyRcloneDefaults fills the rclone tuning of every provider with the top level one
+func applyRcloneDefaults(cfg *Config) {
+	for i := range cfg.RemoteProviders {
+		cfg.RemoteProviders[i].Rclone = cfg.Rclone.merge(cfg.RemoteProviders[i].Rclone)
+	}
+}
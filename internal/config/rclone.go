@@ -0,0 +1,138 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rclone/rclone/fs"
+	"github.com/rclone/rclone/fs/config/obscure"
+
+	// Every rclone backend, a provider type is any rclone remote type (s3, sftp, azureblob, b2, ...)
+	_ "github.com/rclone/rclone/backend/all"
+)
+
+// validateRcloneConfig checks a provider against the option registry of its rclone backend:
+// the type exists, every config key is a known option with a value of the right type,
+// required options are set and passwords are obscured
+func validateRcloneConfig(provider *RemoteProvider) error {
+	info, err := fs.Find(strings.ToLower(provider.Type))
+	if err != nil {
+		return fmt.Errorf("unknown type %q, expected an rclone backend (%s)", provider.Type, strings.Join(rcloneBackends(), ", "))
+	}
+
+	for key, value := range provider.Config {
+		opt := info.Options.Get(strings.ToLower(key))
+		if opt == nil {
+			return fmt.Errorf("unknown %s option %q", info.Name, key)
+		}
+
+		if value == "" {
+			continue
+		}
+
+		if err := opt.Copy().Set(value); err != nil {
+			return fmt.Errorf("invalid %s option %q: %w", info.Name, key, err)
+		}
+
+		if opt.Exclusive && opt.Provider == "" && !isExample(opt, value) {
+			return fmt.Errorf("invalid %s option %q: %q is not one of the allowed values", info.Name, key, value)
+		}
+
+		if opt.IsPassword {
+			if _, err := obscure.Reveal(value); err != nil {
+				return fmt.Errorf("%s option %q must be obscured, use \"pgopher obscure\"", info.Name, key)
+			}
+		}
+	}
+
+	for _, opt := range info.Options {
+		// options filtered by sub-provider (ex: s3 provider: Minio) are not checked
+		if !opt.Required || opt.Provider != "" || (opt.Default != nil && opt.Default != "") {
+			continue
+		}
+		if strings.TrimSpace(configValue(provider.Config, opt.Name)) == "" {
+			return fmt.Errorf("%s requires %q in config", info.Name, opt.Name)
+		}
+	}
+
+	return nil
+}
+
+// validateRclone checks the process wide rclone settings
+func (c *Config) validateRclone() error {
+	if c.Rclone.LogLevel != "" {
+		var level fs.LogLevel
+		if err := level.Set(c.Rclone.LogLevel); err != nil {
+			return fmt.Errorf("log_level: %w", err)
+		}
+	}
+
+	return validateRcloneTuning(c.Rclone.RcloneTuning)
+}
+
+// validateRcloneTuning parses the tuning values the way rclone flags do
+func validateRcloneTuning(tuning RcloneTuning) error {
+	if tuning.BwLimit != "" {
+		var bwLimit fs.BwTimetable
+		if err := bwLimit.Set(tuning.BwLimit); err != nil {
+			return fmt.Errorf("bwlimit: %w", err)
+		}
+	}
+
+	for name, value := range map[string]string{
+		"buffer_size":             tuning.BufferSize,
+		"streaming_upload_cutoff": tuning.StreamingUploadCutoff,
+	} {
+		if value == "" {
+			continue
+		}
+		var size fs.SizeSuffix
+		if err := size.Set(value); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	for name, value := range map[string]int{
+		"transfers":         tuning.Transfers,
+		"connect_timeout":   tuning.ConnectTimeout,
+		"timeout":           tuning.Timeout,
+		"low_level_retries": tuning.LowLevelRetries,
+	} {
+		if value < 0 {
+			return fmt.Errorf("%s must be 0 or greater, got %d", name, value)
+		}
+	}
+
+	return nil
+}
+
+func configValue(config map[string]string, name string) string {
+	for key, value := range config {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+func isExample(opt *fs.Option, value string) bool {
+	for _, example := range opt.Examples {
+		if example.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// rcloneBackends returns the registered backend names, used in error messages
+func rcloneBackends() []string {
+	names := make([]string, 0, len(fs.Registry))
+	for _, info := range fs.Registry {
+		if !info.Hide {
+			names = append(names, info.FileName())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -154,3 +154,29 @@ func stringsLookup(key string) ([]string, bool) {
 	_ = os.Unsetenv(key)
 	return strings.Split(valueStr, ","), true
 }
+
+// splitSchedules splits on ";" when present, so cron lists like "0 3 * * 1,3" survive
+func splitSchedules(value string) []string {
+	if strings.Contains(value, ";") {
+		return strings.Split(value, ";")
+	}
+	return strings.Split(value, ",")
+}
+
+func schedulesOrEmpty(key string, defaultValue []string) []string {
+	valueStr, ok := os.LookupEnv(key)
+	if !ok {
+		return defaultValue
+	}
+	_ = os.Unsetenv(key)
+	return splitSchedules(valueStr)
+}
+
+func schedulesLookup(key string) ([]string, bool) {
+	valueStr, ok := os.LookupEnv(key)
+	if !ok {
+		return nil, false
+	}
+	_ = os.Unsetenv(key)
+	return splitSchedules(valueStr), true
+}
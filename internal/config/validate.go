@@ -3,10 +3,12 @@ package config
 import (
 	"fmt"
 	"net"
+	"os"
 	"regexp"
 	"strings"
 
 	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/pgopher/internal/utils"
 )
 
 // Validate validates the entire configuration
@@ -23,6 +25,10 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("local backup config: %w", err)
 	}
 
+	if err := c.validateRclone(); err != nil {
+		return fmt.Errorf("rclone config: %w", err)
+	}
+
 	if err := c.validateRemoteProviders(); err != nil {
 		return fmt.Errorf("remote providers config: %w", err)
 	}
@@ -31,6 +37,46 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("notify config: %w", err)
 	}
 
+	if err := c.validateScheduler(); err != nil {
+		return fmt.Errorf("scheduler config: %w", err)
+	}
+
+	if c.Catalog.TTL < -1 {
+		return fmt.Errorf("catalog config: ttl must be -1 (always list) or greater, got %d", c.Catalog.TTL)
+	}
+
+	if c.Restore.SnapshotKeep < 0 {
+		return fmt.Errorf("restore config: snapshot_keep must be 0 or greater, got %d", c.Restore.SnapshotKeep)
+	}
+
+	if c.Restore.KeepOldHours < 0 {
+		return fmt.Errorf("restore config: keep_old_hours must be 0 or greater, got %d", c.Restore.KeepOldHours)
+	}
+
+	if c.Restore.Drain.Timeout < 0 {
+		return fmt.Errorf("restore config: drain.timeout must be 0 or greater, got %d", c.Restore.Drain.Timeout)
+	}
+
+	if err := c.validateHooks(); err != nil {
+		return fmt.Errorf("hooks config: %w", err)
+	}
+
+	if err := c.validatePostRestore(); err != nil {
+		return fmt.Errorf("restore config: post_restore: %w", err)
+	}
+
+	if err := c.validateRestoreTargets(); err != nil {
+		return fmt.Errorf("restore targets config: %w", err)
+	}
+
+	if err := c.validateWAL(); err != nil {
+		return fmt.Errorf("wal config: %w", err)
+	}
+
+	if err := c.validateReplication(); err != nil {
+		return fmt.Errorf("replication config: %w", err)
+	}
+
 	return nil
 }
 
@@ -97,12 +143,34 @@ func (c *Config) validateLocalBackup() error {
 
 	if len(lb.Schedule) > 0 {
 		for _, schedule := range lb.Schedule {
-			if !isValidTimeFormat(schedule) {
-				return fmt.Errorf("invalid schedule format '%s', expected HH:MM", schedule)
+			if _, err := utils.ParseSchedule(schedule); err != nil {
+				return fmt.Errorf("invalid schedule '%s', expected HH:MM, cron expression or descriptor: %w", schedule, err)
 			}
 		}
 	}
 
+	if err := validateHeartbeat(lb.Heartbeat); err != nil {
+		return fmt.Errorf("heartbeat: %w", err)
+	}
+
+	if err := validateOverlap(lb.Overlap, lb.Jitter); err != nil {
+		return err
+	}
+
+	if err := validateMethod(lb.Method); err != nil {
+		return err
+	}
+
+	if lb.Timeout < 0 {
+		return fmt.Errorf("timeout must be 0 or greater, got %d", lb.Timeout)
+	}
+
+	if lb.Retry != nil {
+		if err := validateRetry(*lb.Retry); err != nil {
+			return fmt.Errorf("retry: %w", err)
+		}
+	}
+
 	// Validate retention - must have at least one strategy OR none
 	hasRetentionDays := lb.Retention.HasRetentionDays()
 	hasMaxBackups := lb.Retention.HasMaxBackups()
@@ -150,16 +218,24 @@ func (c *Config) validateRemoteProviders() error {
 			return fmt.Errorf("provider[%d]: name is required", i)
 		}
 
-		if strings.TrimSpace(provider.Type) == "" {
-			return fmt.Errorf("provider[%d] (%s): type is required", i, provider.Name)
+		if provider.Remote != "" {
+			if provider.RcloneConfig != "" {
+				if _, err := os.Stat(provider.RcloneConfig); err != nil {
+					return fmt.Errorf("provider[%d] (%s): rclone_config: %w", i, provider.Name, err)
+				}
+			}
+		} else if strings.TrimSpace(provider.Type) == "" {
+			return fmt.Errorf("provider[%d] (%s): type or remote is required", i, provider.Name)
+		} else if provider.RcloneConfig != "" {
+			return fmt.Errorf("provider[%d] (%s): rclone_config requires remote", i, provider.Name)
 		}
 
 		if provider.Enabled && strings.TrimSpace(provider.Path) == "" {
 			return fmt.Errorf("provider[%d] (%s): path is required when enabled", i, provider.Name)
 		}
 
-		// ✅ Validar configurações específicas de cada tipo
-		if provider.Enabled {
+		// ✅ Validar configurações específicas de cada tipo (remotes do rclone.conf são validados pelo rclone)
+		if provider.Enabled && provider.Remote == "" {
 			if err := validateProviderConfig(i, &provider); err != nil {
 				return err
 			}
@@ -167,15 +243,41 @@ func (c *Config) validateRemoteProviders() error {
 
 		if len(provider.Schedule) > 0 {
 			for _, schedule := range provider.Schedule {
-				if !isValidTimeFormat(schedule) {
-					return fmt.Errorf("provider[%d] (%s): invalid schedule format '%s', expected HH:MM",
-						i, provider.Name, schedule)
+				if _, err := utils.ParseSchedule(schedule); err != nil {
+					return fmt.Errorf("provider[%d] (%s): invalid schedule '%s', expected HH:MM, cron expression or descriptor: %w",
+						i, provider.Name, schedule, err)
 				}
 			}
 		} else if provider.Enabled {
 			logr.Warnf("Provider '%s' is enabled but has no schedule configured", provider.Name)
 		}
 
+		if err := validateHeartbeat(provider.Heartbeat); err != nil {
+			return fmt.Errorf("provider[%d] (%s): heartbeat: %w", i, provider.Name, err)
+		}
+
+		if err := validateOverlap(provider.Overlap, provider.Jitter); err != nil {
+			return fmt.Errorf("provider[%d] (%s): %w", i, provider.Name, err)
+		}
+
+		if err := validateMethod(provider.Method); err != nil {
+			return fmt.Errorf("provider[%d] (%s): %w", i, provider.Name, err)
+		}
+
+		if provider.Retry != nil {
+			if err := validateRetry(*provider.Retry); err != nil {
+				return fmt.Errorf("provider[%d] (%s): retry: %w", i, provider.Name, err)
+			}
+		}
+
+		if err := validateRcloneTuning(provider.Rclone); err != nil {
+			return fmt.Errorf("provider[%d] (%s): rclone: %w", i, provider.Name, err)
+		}
+
+		if err := validateObjectLock(&provider); err != nil {
+			return fmt.Errorf("provider[%d] (%s): object_lock: %w", i, provider.Name, err)
+		}
+
 		//if provider.ScheduleDay != nil {
 		//	day := *provider.ScheduleDay
 		//	if day < 0 || day > 6 {
@@ -207,8 +309,12 @@ func (c *Config) validateRemoteProviders() error {
 	return nil
 }
 
-// ✅ validateProviderConfig valida configurações específicas de cada tipo de provider
+// ✅ validateProviderConfig valida as opções no registro do rclone, depois as regras extras dos tipos mais usados
 func validateProviderConfig(index int, provider *RemoteProvider) error {
+	if err := validateRcloneConfig(provider); err != nil {
+		return fmt.Errorf("provider[%d] (%s): %w", index, provider.Name, err)
+	}
+
 	switch strings.ToLower(provider.Type) {
 	case "s3":
 		return validateS3Config(index, provider)
@@ -218,11 +324,9 @@ func validateProviderConfig(index int, provider *RemoteProvider) error {
 		return validateDropboxConfig(index, provider)
 	case "mega":
 		return validateMegaConfig(index, provider)
-	case "google cloud storage":
+	case "gcs", "google cloud storage":
 		return validateGCSConfig(index, provider)
 	default:
-		logr.Warnf("Provider[%d] (%s): unknown type '%s', skipping specific validation",
-			index, provider.Name, provider.Type)
 		return nil
 	}
 }
@@ -230,6 +334,9 @@ func validateProviderConfig(index int, provider *RemoteProvider) error {
 // ✅ validateS3Config valida configurações do S3
 func validateS3Config(index int, provider *RemoteProvider) error {
 	required := []string{"access_key_id", "secret_access_key"}
+	if provider.Config["env_auth"] == "true" {
+		required = nil // credentials from the environment or the instance role
+	}
 
 	for _, field := range required {
 		if val, ok := provider.Config[field]; !ok || strings.TrimSpace(val) == "" {
@@ -405,10 +512,213 @@ func validateGCSConfig(index int, provider *RemoteProvider) error {
 	return nil
 }
 
+// validateRestoreTargets validates restore targets, empty fields are inherited from database
+func (c *Config) validateRestoreTargets() error {
+	names := make(map[string]bool)
+
+	for i, target := range c.RestoreTargets {
+		if strings.TrimSpace(target.Name) == "" {
+			return fmt.Errorf("restore_targets[%d]: name is required", i)
+		}
+		if names[target.Name] {
+			return fmt.Errorf("restore_targets[%d]: duplicate name '%s'", i, target.Name)
+		}
+		names[target.Name] = true
+
+		db := target.Database
+		if db.Host != "" && !isValidHost(db.Host) {
+			return fmt.Errorf("restore_targets[%d] (%s): host has invalid format: %s", i, target.Name, db.Host)
+		}
+		if db.Port != 0 && (db.Port < 1 || db.Port > 65535) {
+			return fmt.Errorf("restore_targets[%d] (%s): port must be between 1 and 65535, got %d", i, target.Name, db.Port)
+		}
+		if db.Name != "" && !isValidPostgresName(db.Name) {
+			return fmt.Errorf("restore_targets[%d] (%s): name contains invalid characters: %s", i, target.Name, db.Name)
+		}
+	}
+
+	return nil
+}
+
+// validateReplication checks the jobs copy between existing destinations, job names share
+// the scheduler namespace with "local" and the providers
+func (c *Config) validateReplication() error {
+	names := map[string]bool{"local": true}
+	providers := make(map[string]bool)
+	for _, provider := range c.RemoteProviders {
+		names[provider.Name] = true
+		providers[provider.Name] = provider.Enabled
+	}
+
+	for i, job := range c.Replication {
+		if strings.TrimSpace(job.Name) == "" {
+			return fmt.Errorf("replication[%d]: name is required", i)
+		}
+		if names[job.Name] {
+			return fmt.Errorf("replication[%d]: name '%s' is already used by a provider or another job", i, job.Name)
+		}
+		names[job.Name] = true
+
+		switch {
+		case job.From == "local":
+			if !c.LocalBackup.Enabled {
+				return fmt.Errorf("replication[%d] (%s): from local requires the local backup", i, job.Name)
+			}
+		case !providers[job.From]:
+			return fmt.Errorf("replication[%d] (%s): from '%s' is not an enabled provider", i, job.Name, job.From)
+		}
+
+		if !providers[job.To] {
+			return fmt.Errorf("replication[%d] (%s): to '%s' is not an enabled provider", i, job.Name, job.To)
+		}
+		if job.From == job.To {
+			return fmt.Errorf("replication[%d] (%s): from and to must be different", i, job.Name)
+		}
+
+		if len(job.Schedule) == 0 {
+			return fmt.Errorf("replication[%d] (%s): schedule is required", i, job.Name)
+		}
+		for _, schedule := range job.Schedule {
+			if _, err := utils.ParseSchedule(schedule); err != nil {
+				return fmt.Errorf("replication[%d] (%s): invalid schedule '%s': %w", i, job.Name, schedule, err)
+			}
+		}
+
+		if job.MaxAge < 0 || job.Timeout < 0 {
+			return fmt.Errorf("replication[%d] (%s): max_age and timeout must be 0 or greater", i, job.Name)
+		}
+
+		if err := validateOverlap(job.Overlap, nil); err != nil {
+			return fmt.Errorf("replication[%d] (%s): %w", i, job.Name, err)
+		}
+
+		if job.Retry != nil {
+			if err := validateRetry(*job.Retry); err != nil {
+				return fmt.Errorf("replication[%d] (%s): retry: %w", i, job.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateScheduler validates scheduler defaults
+func (c *Config) validateScheduler() error {
+	sc := c.Scheduler
+
+	if err := validateOverlap(sc.Overlap, &sc.Jitter); err != nil {
+		return err
+	}
+
+	if sc.MaxConcurrent < 0 {
+		return fmt.Errorf("SCHEDULER_MAX_CONCURRENT cannot be negative, got %d", sc.MaxConcurrent)
+	}
+
+	if err := validateRetry(sc.Retry); err != nil {
+		return fmt.Errorf("retry: %w", err)
+	}
+
+	return nil
+}
+
+// validateRetry validates a retry policy
+func validateRetry(retry RetryConfig) error {
+	if retry.MaxAttempts < 0 {
+		return fmt.Errorf("max_attempts cannot be negative, got %d", retry.MaxAttempts)
+	}
+	if retry.MaxAttempts > 20 {
+		logr.Warnf("retry max_attempts=%d is very high", retry.MaxAttempts)
+	}
+
+	if retry.InitialDelay < 0 || retry.MaxDelay < 0 {
+		return fmt.Errorf("initial_delay and max_delay cannot be negative")
+	}
+
+	if retry.MaxDelay > 0 && retry.InitialDelay > retry.MaxDelay {
+		return fmt.Errorf("initial_delay (%d) cannot be greater than max_delay (%d)", retry.InitialDelay, retry.MaxDelay)
+	}
+
+	if retry.Multiplier < 0 {
+		return fmt.Errorf("multiplier cannot be negative, got %v", retry.Multiplier)
+	}
+
+	validClasses := map[string]bool{"network": true, "timeout": true, "dump": true, "all": true}
+	for _, class := range retry.RetryOn {
+		if !validClasses[strings.ToLower(strings.TrimSpace(class))] {
+			return fmt.Errorf("retry_on must contain only: network, timeout, dump, all, got '%s'", class)
+		}
+	}
+
+	return nil
+}
+
+// validateMethod validates the backup method of a job
+func validateMethod(method string) error {
+	switch method {
+	case "", MethodLogical, MethodPhysical:
+		return nil
+	default:
+		return fmt.Errorf("method must be %s or %s, got %s", MethodLogical, MethodPhysical, method)
+	}
+}
+
+// validateOverlap validates overlap mode and jitter of a job
+func validateOverlap(overlap string, jitter *int) error {
+	switch strings.ToLower(overlap) {
+	case "", "skip", "queue":
+	default:
+		return fmt.Errorf("overlap must be one of: skip, queue, got '%s'", overlap)
+	}
+
+	if jitter != nil && *jitter < 0 {
+		return fmt.Errorf("jitter cannot be negative, got %d", *jitter)
+	}
+
+	if jitter != nil && *jitter > 3600 {
+		logr.Warnf("jitter=%ds is very high (>1h)", *jitter)
+	}
+
+	return nil
+}
+
+// validateHeartbeat validates heartbeat kind and ping URLs
+func validateHeartbeat(hb HeartbeatConfig) error {
+	switch strings.ToLower(hb.Kind) {
+	case "", "healthchecks", "uptime_kuma":
+	default:
+		return fmt.Errorf("kind must be one of: healthchecks, uptime_kuma, got '%s'", hb.Kind)
+	}
+
+	urls := map[string]string{
+		"url":         hb.URL,
+		"start_url":   hb.StartURL,
+		"success_url": hb.SuccessURL,
+		"fail_url":    hb.FailURL,
+	}
+	for field, value := range urls {
+		if value == "" {
+			continue
+		}
+		if !strings.HasPrefix(value, "http://") && !strings.HasPrefix(value, "https://") {
+			return fmt.Errorf("%s must start with http:// or https://", field)
+		}
+	}
+
+	if hb.Timeout < 0 {
+		return fmt.Errorf("timeout cannot be negative, got %d", hb.Timeout)
+	}
+
+	return nil
+}
+
 // validateNotification validate notify settings
 func (c *Config) validateNotification() error {
 	notif := c.Notification
 
+	if err := validateDigest(notif.Digest); err != nil {
+		return fmt.Errorf("digest: %w", err)
+	}
+
 	if !notif.IsMails() && notif.DiscordWebhookURL == "" && notif.TelegramBotToken == "" {
 		return nil
 	}
@@ -454,6 +764,27 @@ func (c *Config) validateNotification() error {
 	return nil
 }
 
+// validateDigest validates the digest schedule and thresholds
+func validateDigest(digest DigestConfig) error {
+	if !digest.Enabled {
+		return nil
+	}
+
+	if strings.TrimSpace(digest.Schedule) == "" {
+		return fmt.Errorf("DIGEST_SCHEDULE is required when digest is enabled")
+	}
+
+	if _, err := utils.ParseSchedule(digest.Schedule); err != nil {
+		return fmt.Errorf("invalid DIGEST_SCHEDULE '%s': %w", digest.Schedule, err)
+	}
+
+	if digest.StaleAfterHours < 0 {
+		return fmt.Errorf("DIGEST_STALE_AFTER_HOURS cannot be negative, got %d", digest.StaleAfterHours)
+	}
+
+	return nil
+}
+
 // Validation helper functions
 
 // isValidHost validates whether the host is valid (hostname or IP)
@@ -474,14 +805,124 @@ func isValidPostgresName(name string) bool {
 	return nameRegex.MatchString(name)
 }
 
-// isValidTimeFormat validates HH:MM format
-func isValidTimeFormat(timeStr string) bool {
-	timeRegex := regexp.MustCompile(`^([0-1][0-9]|2[0-3]):([0-5][0-9])$`)
-	return timeRegex.MatchString(timeStr)
-}
-
 // isValidEmail validate email format
 func isValidEmail(email string) bool {
 	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
 	return emailRegex.MatchString(email)
 }
+
+var privilegesRegex = regexp.MustCompile(`^[A-Za-z ,]+$`)
+
+// validatePostRestore validates the post-restore steps, privileges are inserted in GRANT statements
+func (c *Config) validatePostRestore() error {
+	post := c.Restore.PostRestore
+
+	switch post.Analyze {
+	case "", "analyze", "vacuum_analyze":
+	default:
+		return fmt.Errorf("analyze must be analyze or vacuum_analyze, got %s", post.Analyze)
+	}
+
+	for i, grant := range post.Grants {
+		if strings.TrimSpace(grant.Role) == "" {
+			return fmt.Errorf("grants[%d]: role is required", i)
+		}
+		if !privilegesRegex.MatchString(grant.Privileges) {
+			return fmt.Errorf("grants[%d] (%s): privileges must be a list like \"SELECT, INSERT\", got %q", i, grant.Role, grant.Privileges)
+		}
+		if grant.Sequences != "" && !privilegesRegex.MatchString(grant.Sequences) {
+			return fmt.Errorf("grants[%d] (%s): sequences must be a list like \"USAGE, SELECT\", got %q", i, grant.Role, grant.Sequences)
+		}
+	}
+
+	for _, file := range post.SQLFiles {
+		if _, err := os.Stat(file); err != nil {
+			return fmt.Errorf("sql_files: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validateObjectLock checks the retention settings, object lock is set through the S3 API
+// so it needs an inline s3 provider
+func validateObjectLock(provider *RemoteProvider) error {
+	lock := provider.ObjectLock
+	if !lock.Enabled() {
+		if lock.Days != 0 {
+			return fmt.Errorf("days requires mode")
+		}
+		return nil
+	}
+
+	if provider.Remote != "" || !strings.EqualFold(provider.Type, "s3") {
+		return fmt.Errorf("only supported by s3 providers with type and config")
+	}
+
+	switch strings.ToLower(lock.Mode) {
+	case "":
+	case ObjectLockGovernance, ObjectLockCompliance:
+		if lock.Days <= 0 {
+			return fmt.Errorf("days must be greater than 0 with mode %s", lock.Mode)
+		}
+	default:
+		return fmt.Errorf("invalid mode '%s', expected governance or compliance", lock.Mode)
+	}
+
+	return nil
+}
+
+// validateWAL checks the archive has at least one destination and the providers exist
+func (c *Config) validateWAL() error {
+	if !c.WAL.Enabled {
+		return nil
+	}
+
+	if !c.WAL.Local && len(c.WAL.Providers) == 0 {
+		return fmt.Errorf("enable local or set at least one provider")
+	}
+
+	for _, name := range c.WAL.Providers {
+		found := false
+		for _, provider := range c.RemoteProviders {
+			if provider.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("provider %s not found in providers", name)
+		}
+	}
+
+	return nil
+}
+
+// validateHooks validates every hook, a hook runs either a command or SQL
+func (c *Config) validateHooks() error {
+	events := map[string][]HookConfig{
+		"pre_backup":   c.Hooks.PreBackup,
+		"post_backup":  c.Hooks.PostBackup,
+		"on_failure":   c.Hooks.OnFailure,
+		"pre_restore":  c.Hooks.PreRestore,
+		"post_restore": c.Hooks.PostRestore,
+	}
+
+	for event, hooks := range events {
+		for i, hook := range hooks {
+			if (hook.Command == "") == (hook.SQL == "") {
+				return fmt.Errorf("%s[%d]: set either command or sql", event, i)
+			}
+			if hook.Timeout < 0 {
+				return fmt.Errorf("%s[%d]: timeout must be 0 or greater, got %d", event, i, hook.Timeout)
+			}
+			switch hook.OnError {
+			case "", "abort", "continue":
+			default:
+				return fmt.Errorf("%s[%d]: on_error must be abort or continue, got %s", event, i, hook.OnError)
+			}
+		}
+	}
+
+	return nil
+}
@@ -25,6 +25,10 @@ func NewClient(cfg *config.DatabaseConfig) *Client {
 	return &Client{config: cfg}
 }
 
+func (c *Client) Name() string {
+	return c.config.Name
+}
+
 func (c *Client) TestConnection(ctx context.Context) error {
 	conn, err := pgx.Connect(ctx, c.config.ConnectionString())
 	if err != nil {
@@ -99,12 +103,12 @@ func (c *Client) CountConnections(ctx context.Context) (int, error) {
 	}()
 
 	var count int
-	err = conn.QueryRow(ctx, fmt.Sprintf(`
+	err = conn.QueryRow(ctx, `
         SELECT COUNT(*) 
         FROM pg_stat_activity 
-        WHERE datname = '%s' 
+        WHERE datname = $1 
         AND pid <> pg_backend_pid()
-    `, c.config.Name)).Scan(&count)
+    `, c.config.Name).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to check connections: %w", err)
 
@@ -122,22 +126,21 @@ func (c *Client) ListConnections(ctx context.Context) ([]ConnectionInfo, error)
 	defer func() {
 		_ = conn.Close(ctx)
 	}()
-	query := fmt.Sprintf(`
+	query := `
         SELECT 
             pid,
-            usename,
-            COALESCE(application_name, 'unknown'),
+            COALESCE(usename, ''),
+            COALESCE(NULLIF(application_name, ''), 'unknown'),
             COALESCE(client_addr::text, 'local'),
-            state,
-            query_start
+            COALESCE(state, ''),
+            COALESCE(query_start, backend_start)
         FROM pg_stat_activity 
-        WHERE datname = '%s' 
+        WHERE datname = $1 
         AND pid <> pg_backend_pid()
-        ORDER BY query_start DESC
-        LIMIT 10
-    `, c.config.Name)
+        ORDER BY query_start DESC NULLS LAST
+    `
 
-	rows, err := conn.Query(ctx, query)
+	rows, err := conn.Query(ctx, query, c.config.Name)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to list connections: %w", err)
@@ -167,3 +170,70 @@ func (c *Client) ListConnections(ctx context.Context) ([]ConnectionInfo, error)
 
 	return connections, nil
 }
+
+// maintenanceConfig points to the "postgres" database of the same server,
+// used for statements that cannot run inside the target database
+func (c *Client) maintenanceConfig() *config.DatabaseConfig {
+	cfg := *c.config
+	cfg.Name = "postgres"
+	return &cfg
+}
+
+func (c *Client) DatabaseExists(ctx context.Context) (bool, error) {
+	conn, err := pgx.Connect(ctx, c.maintenanceConfig().ConnectionString())
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = conn.Close(ctx)
+	}()
+
+	var exists bool
+	err = conn.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM pg_database WHERE datname = $1)", c.config.Name).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check database: %w", err)
+	}
+
+	return exists, nil
+}
+
+// CreateDatabase creates the configured database, returns false if it already exists
+func (c *Client) CreateDatabase(ctx context.Context) (bool, error) {
+	exists, err := c.DatabaseExists(ctx)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return false, nil
+	}
+
+	conn, err := pgx.Connect(ctx, c.maintenanceConfig().ConnectionString())
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = conn.Close(ctx)
+	}()
+
+	query := fmt.Sprintf("CREATE DATABASE %s", pgx.Identifier{c.config.Name}.Sanitize())
+	if _, err := conn.Exec(ctx, query); err != nil {
+		return false, fmt.Errorf("failed to create database: %w", err)
+	}
+
+	return true, nil
+}
+
+// Exec runs a statement in the configured database, without arguments several statements
+// separated by ";" are allowed (simple protocol)
+func (c *Client) Exec(ctx context.Context, query string) error {
+	conn, err := pgx.Connect(ctx, c.config.ConnectionString())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close(ctx)
+	}()
+
+	_, err = conn.Exec(ctx, query)
+	return err
+}
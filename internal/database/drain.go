@@ -0,0 +1,173 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// DrainOptions controls how sessions are cleared before a restore
+type DrainOptions struct {
+	Terminate bool          // pg_terminate_backend the remaining sessions, otherwise only wait
+	AllowApps []string      // application names left alone, ex: monitoring agents
+	Timeout   time.Duration // max wait for the sessions to end
+}
+
+// Drain revokes CONNECT from PUBLIC, optionally terminates the other sessions and waits until
+// none is left. The returned release function restores CONNECT and must be called once the
+// database can accept connections again, even when Drain fails.
+func (c *Client) Drain(ctx context.Context, opts DrainOptions) (func(ctx context.Context) error, error) {
+	release := func(ctx context.Context) error { return nil }
+
+	canConnect, err := c.PublicCanConnect(ctx)
+	if err != nil {
+		return release, err
+	}
+
+	if canConnect {
+		if err := c.RevokeConnect(ctx); err != nil {
+			return release, err
+		}
+		release = c.GrantConnect
+	}
+
+	if opts.Terminate {
+		if _, err := c.TerminateConnections(ctx, opts.AllowApps); err != nil {
+			return release, err
+		}
+	}
+
+	if err := c.WaitForDrain(ctx, opts.AllowApps, opts.Timeout); err != nil {
+		return release, err
+	}
+
+	return release, nil
+}
+
+// PublicCanConnect reports whether PUBLIC holds CONNECT on the database (the default)
+func (c *Client) PublicCanConnect(ctx context.Context) (bool, error) {
+	conn, err := pgx.Connect(ctx, c.maintenanceConfig().ConnectionString())
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = conn.Close(ctx)
+	}()
+
+	var granted bool
+	err = conn.QueryRow(ctx, `
+        SELECT EXISTS (
+            SELECT 1
+            FROM pg_database d, aclexplode(COALESCE(d.datacl, acldefault('d', d.datdba))) a
+            WHERE d.datname = $1
+            AND a.grantee = 0
+            AND a.privilege_type = 'CONNECT'
+        )
+    `, c.config.Name).Scan(&granted)
+	if err != nil {
+		return false, fmt.Errorf("failed to check connect privilege: %w", err)
+	}
+
+	return granted, nil
+}
+
+// RevokeConnect stops new sessions of regular users, superusers and the owner can still connect
+func (c *Client) RevokeConnect(ctx context.Context) error {
+	return c.execMaintenance(ctx, fmt.Sprintf("REVOKE CONNECT ON DATABASE %s FROM PUBLIC",
+		pgx.Identifier{c.config.Name}.Sanitize()))
+}
+
+func (c *Client) GrantConnect(ctx context.Context) error {
+	return c.execMaintenance(ctx, fmt.Sprintf("GRANT CONNECT ON DATABASE %s TO PUBLIC",
+		pgx.Identifier{c.config.Name}.Sanitize()))
+}
+
+// TerminateConnections terminates the other sessions of the database, except the
+// allowed application names, and returns how many were terminated
+func (c *Client) TerminateConnections(ctx context.Context, allowApps []string) (int, error) {
+	conn, err := pgx.Connect(ctx, c.maintenanceConfig().ConnectionString())
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = conn.Close(ctx)
+	}()
+
+	// pgx sends a nil slice as NULL and "= ANY(NULL)" matches no session, hence the COALESCE
+	var terminated int
+	err = conn.QueryRow(ctx, `
+        SELECT COUNT(*) FILTER (WHERE pg_terminate_backend(pid))
+        FROM pg_stat_activity
+        WHERE datname = $1
+        AND pid <> pg_backend_pid()
+        AND NOT (COALESCE(application_name, '') = ANY(COALESCE($2::text[], '{}')))
+    `, c.config.Name, allowApps).Scan(&terminated)
+	if err != nil {
+		return 0, fmt.Errorf("failed to terminate connections: %w", err)
+	}
+
+	return terminated, nil
+}
+
+// WaitForDrain polls until no session other than the allowed ones is left
+func (c *Client) WaitForDrain(ctx context.Context, allowApps []string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		count, err := c.countConnections(ctx, allowApps)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%d session(s) still connected after %s", count, timeout)
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (c *Client) countConnections(ctx context.Context, allowApps []string) (int, error) {
+	conn, err := pgx.Connect(ctx, c.maintenanceConfig().ConnectionString())
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = conn.Close(ctx)
+	}()
+
+	var count int
+	err = conn.QueryRow(ctx, `
+        SELECT COUNT(*)
+        FROM pg_stat_activity
+        WHERE datname = $1
+        AND pid <> pg_backend_pid()
+        AND NOT (COALESCE(application_name, '') = ANY(COALESCE($2::text[], '{}')))
+    `, c.config.Name, allowApps).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check connections: %w", err)
+	}
+
+	return count, nil
+}
+
+func (c *Client) execMaintenance(ctx context.Context, query string) error {
+	conn, err := pgx.Connect(ctx, c.maintenanceConfig().ConnectionString())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close(ctx)
+	}()
+
+	if _, err := conn.Exec(ctx, query); err != nil {
+		return fmt.Errorf("failed to execute %q: %w", query, err)
+	}
+	return nil
+}
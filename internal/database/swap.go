@@ -0,0 +1,203 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SwapTimeFormat is the suffix of the databases created by a blue/green restore,
+// ex: mydb_restore_20261001030000 and mydb_old_20261001030000
+const SwapTimeFormat = "20060102150405"
+
+const maxIdentifierLength = 63
+
+// WithName returns a client for another database of the same server
+func (c *Client) WithName(name string) *Client {
+	cfg := *c.config
+	cfg.Name = name
+	return NewClient(&cfg)
+}
+
+// SwapNames returns the names used by a blue/green restore of the database at t
+func (c *Client) SwapNames(t time.Time) (fresh, old string, err error) {
+	suffix := t.Format(SwapTimeFormat)
+	fresh = fmt.Sprintf("%s_restore_%s", c.config.Name, suffix)
+	old = fmt.Sprintf("%s_old_%s", c.config.Name, suffix)
+
+	if len(fresh) > maxIdentifierLength {
+		return "", "", fmt.Errorf("database name %s is too long for a blue/green restore (max %d characters)",
+			c.config.Name, maxIdentifierLength-len(fresh)+len(c.config.Name))
+	}
+	return fresh, old, nil
+}
+
+// CountTables returns the number of user tables, used to validate a restored database
+func (c *Client) CountTables(ctx context.Context) (int, error) {
+	conn, err := pgx.Connect(ctx, c.config.ConnectionString())
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = conn.Close(ctx)
+	}()
+
+	var count int
+	err = conn.QueryRow(ctx, `
+        SELECT COUNT(*)
+        FROM information_schema.tables
+        WHERE table_schema NOT IN ('pg_catalog', 'information_schema')
+    `).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count tables: %w", err)
+	}
+
+	return count, nil
+}
+
+// Swap renames the configured database to old and fresh to the configured name in a single
+// transaction, terminating the connections to both first. When the configured database does
+// not exist fresh is only renamed. Retries while clients keep reconnecting.
+func (c *Client) Swap(ctx context.Context, fresh, old string, attempts int) error {
+	exists, err := c.DatabaseExists(ctx)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if lastErr = c.swap(ctx, fresh, old, exists); lastErr == nil {
+			return nil
+		}
+
+		var pgErr *pgconn.PgError
+		if !errors.As(lastErr, &pgErr) || pgErr.Code != "55006" { // object_in_use
+			return lastErr
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * 200 * time.Millisecond):
+		}
+	}
+
+	return fmt.Errorf("database still in use after %d attempt(s): %w", attempts, lastErr)
+}
+
+func (c *Client) swap(ctx context.Context, fresh, old string, exists bool) error {
+	conn, err := pgx.Connect(ctx, c.maintenanceConfig().ConnectionString())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close(ctx)
+	}()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin swap: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if _, err := tx.Exec(ctx, `
+        SELECT pg_terminate_backend(pid)
+        FROM pg_stat_activity
+        WHERE datname IN ($1, $2)
+        AND pid <> pg_backend_pid()
+    `, c.config.Name, fresh); err != nil {
+		return fmt.Errorf("failed to terminate connections: %w", err)
+	}
+
+	if exists {
+		if err := rename(ctx, tx, c.config.Name, old); err != nil {
+			return err
+		}
+	}
+	if err := rename(ctx, tx, fresh, c.config.Name); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit swap: %w", err)
+	}
+	return nil
+}
+
+func rename(ctx context.Context, tx pgx.Tx, from, to string) error {
+	query := fmt.Sprintf("ALTER DATABASE %s RENAME TO %s",
+		pgx.Identifier{from}.Sanitize(), pgx.Identifier{to}.Sanitize())
+	if _, err := tx.Exec(ctx, query); err != nil {
+		return fmt.Errorf("failed to rename %s to %s: %w", from, to, err)
+	}
+	return nil
+}
+
+// DropDatabase drops the configured database, terminating its connections (PostgreSQL 13+)
+func (c *Client) DropDatabase(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, c.maintenanceConfig().ConnectionString())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close(ctx)
+	}()
+
+	query := fmt.Sprintf("DROP DATABASE IF EXISTS %s WITH (FORCE)", pgx.Identifier{c.config.Name}.Sanitize())
+	if _, err := conn.Exec(ctx, query); err != nil {
+		return fmt.Errorf("failed to drop database %s: %w", c.config.Name, err)
+	}
+	return nil
+}
+
+// DropOldDatabases drops the databases left by blue/green restores older than keep and
+// returns their names: the replaced databases ("<name>_old_<ts>") and the restore targets
+// of interrupted restores ("<name>_restore_<ts>"). A restore target with connections is
+// skipped, its restore is still running
+func (c *Client) DropOldDatabases(ctx context.Context, keep time.Duration) ([]string, error) {
+	conn, err := pgx.Connect(ctx, c.maintenanceConfig().ConnectionString())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = conn.Close(ctx)
+	}()
+
+	oldPrefix, restorePrefix := c.config.Name+"_old_", c.config.Name+"_restore_"
+	rows, err := conn.Query(ctx, `
+		SELECT datname FROM pg_database d
+		WHERE starts_with(datname, $1)
+		   OR (starts_with(datname, $2)
+		       AND NOT EXISTS (SELECT 1 FROM pg_stat_activity a WHERE a.datname = d.datname))`,
+		oldPrefix, restorePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list old databases: %w", err)
+	}
+	names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to list old databases: %w", err)
+	}
+
+	var dropped []string
+	for _, name := range names {
+		suffix := strings.TrimPrefix(strings.TrimPrefix(name, oldPrefix), restorePrefix)
+		t, err := time.ParseInLocation(SwapTimeFormat, suffix, time.Local)
+		if err != nil || time.Since(t) < keep {
+			continue
+		}
+
+		if err := c.WithName(name).DropDatabase(ctx); err != nil {
+			return dropped, err
+		}
+		dropped = append(dropped, name)
+	}
+
+	return dropped, nil
+}
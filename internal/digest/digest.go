@@ -0,0 +1,218 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/pgopher/internal/catalog"
+	"github.com/BrunoTulio/pgopher/internal/notify"
+	"github.com/BrunoTulio/pgopher/internal/utils"
+)
+
+type (
+	Digest struct {
+		log      logr.Logger
+		opt      *Options
+		catalog  *catalog.Catalog
+		recorder *Recorder
+		notifier notify.Notifier
+		mu       sync.Mutex
+		lastRun  time.Time
+	}
+
+	Report struct {
+		From         time.Time
+		To           time.Time
+		Jobs         []JobSummary
+		Destinations []DestinationSummary
+		Removals     []Removal
+		RemovedBytes int64
+		Warnings     []string
+	}
+
+	JobSummary struct {
+		Name      string
+		Type      string
+		Runs      int
+		Retries   int
+		Failures  int
+		LastError string
+	}
+
+	DestinationSummary struct {
+		Name       string
+		Count      int
+		TotalBytes int64
+		Newest     time.Time
+		NewestAge  time.Duration
+		Err        string
+	}
+)
+
+func New(
+	catalog *catalog.Catalog,
+	recorder *Recorder,
+	notifier notify.Notifier,
+	log logr.Logger,
+) *Digest {
+	return NewWithOptions(catalog, recorder, notifier, log)
+}
+
+func NewWithOptions(
+	catalog *catalog.Catalog,
+	recorder *Recorder,
+	notifier notify.Notifier,
+	log logr.Logger,
+	opts ...FnOptions,
+) *Digest {
+	opt := &Options{}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	return &Digest{
+		log:      log,
+		opt:      opt,
+		catalog:  catalog,
+		recorder: recorder,
+		notifier: notifier,
+		lastRun:  time.Now(),
+	}
+}
+
+// Run builds the report for the period since the previous run and sends it
+func (d *Digest) Run(ctx context.Context) error {
+	d.mu.Lock()
+	from := d.lastRun
+	to := time.Now()
+	d.lastRun = to
+	d.mu.Unlock()
+
+	d.log.Infof("📊 Building digest report (%s → %s)", utils.FormatTime(from), utils.FormatTime(to))
+
+	report := d.Build(ctx, from, to)
+
+	text, err := report.Text()
+	if err != nil {
+		return fmt.Errorf("render text: %w", err)
+	}
+	html, err := report.HTML()
+	if err != nil {
+		return fmt.Errorf("render html: %w", err)
+	}
+
+	if err := d.notifier.Report(ctx, notify.Report{
+		Subject: report.Subject(),
+		Text:    text,
+		HTML:    html,
+	}); err != nil {
+		return fmt.Errorf("send digest: %w", err)
+	}
+
+	d.log.Info("✅ Digest report sent")
+	return nil
+}
+
+func (d *Digest) Build(ctx context.Context, from, to time.Time) Report {
+	jobs, removals := d.recorder.Collect(from)
+
+	report := Report{
+		From:     from,
+		To:       to,
+		Jobs:     summarizeJobs(jobs),
+		Removals: removals,
+	}
+
+	for _, rm := range removals {
+		report.RemovedBytes += rm.Size
+	}
+
+	for _, job := range report.Jobs {
+		if job.Failures > 0 {
+			report.Warnings = append(report.Warnings,
+				fmt.Sprintf("%s: %d of %d run(s) failed", job.Name, job.Failures, job.Runs))
+		}
+	}
+
+	for _, name := range d.opt.Destinations {
+		dest := d.summarizeDestination(ctx, name, to)
+		report.Destinations = append(report.Destinations, dest)
+
+		switch {
+		case dest.Err != "":
+			report.Warnings = append(report.Warnings,
+				fmt.Sprintf("%s: failed to list backups: %s", name, dest.Err))
+		case dest.Count == 0:
+			report.Warnings = append(report.Warnings,
+				fmt.Sprintf("%s: no backups found", name))
+		case d.opt.StaleAfter > 0 && dest.NewestAge > d.opt.StaleAfter:
+			report.Warnings = append(report.Warnings,
+				fmt.Sprintf("%s: no backup in %s", name, formatHours(d.opt.StaleAfter)))
+		}
+	}
+
+	return report
+}
+
+func (d *Digest) summarizeDestination(ctx context.Context, name string, now time.Time) DestinationSummary {
+	summary := DestinationSummary{Name: name}
+
+	files, err := d.catalog.List(ctx, name)
+	if err != nil {
+		d.log.Warnf("Digest: failed to list %s: %v", name, err)
+		summary.Err = err.Error()
+		return summary
+	}
+
+	summary.Count = len(files)
+	for _, f := range files {
+		summary.TotalBytes += f.Size
+		if f.Time.After(summary.Newest) {
+			summary.Newest = f.Time
+		}
+	}
+
+	if !summary.Newest.IsZero() {
+		summary.NewestAge = now.Sub(summary.Newest)
+	}
+
+	return summary
+}
+
+func summarizeJobs(runs []JobRun) []JobSummary {
+	byName := make(map[string]*JobSummary)
+	for _, run := range runs {
+		s, ok := byName[run.Name]
+		if !ok {
+			s = &JobSummary{Name: run.Name, Type: run.Type}
+			byName[run.Name] = s
+		}
+		if !run.Final {
+			s.Retries++
+			continue
+		}
+		s.Runs++
+		if run.Err != "" {
+			s.Failures++
+			s.LastError = run.Err
+		}
+	}
+
+	summaries := make([]JobSummary, 0, len(byName))
+	for _, s := range byName {
+		summaries = append(summaries, *s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Name < summaries[j].Name
+	})
+
+	return summaries
+}
+
+func formatHours(d time.Duration) string {
+	return fmt.Sprintf("%dh", int(d.Hours()))
+}
@@ -0,0 +1,37 @@
+package digest
+
+import (
+	"time"
+
+	"github.com/BrunoTulio/pgopher/internal/config"
+)
+
+type (
+	FnOptions func(*Options)
+
+	Options struct {
+		Destinations []string      // "local" plus enabled remote providers
+		StaleAfter   time.Duration // warn when the newest backup is older
+	}
+)
+
+func WithConfig(cfg *config.Config) FnOptions {
+	return func(opt *Options) {
+		opt.Destinations = nil
+		if cfg.LocalBackup.Enabled {
+			opt.Destinations = append(opt.Destinations, "local")
+		}
+		for _, p := range cfg.RemoteProviders {
+			if p.Enabled {
+				opt.Destinations = append(opt.Destinations, p.Name)
+			}
+		}
+		opt.StaleAfter = cfg.Notification.Digest.StaleAfter()
+	}
+}
+
+func WithStaleAfter(d time.Duration) FnOptions {
+	return func(opt *Options) {
+		opt.StaleAfter = d
+	}
+}
@@ -0,0 +1,83 @@
+package digest
+
+import (
+	"sync"
+	"time"
+)
+
+type (
+	// Recorder keeps job runs and retention removals in memory until the next digest
+	Recorder struct {
+		mu       sync.Mutex
+		jobs     []JobRun
+		removals []Removal
+	}
+
+	JobRun struct {
+		Name     string // ex: "local", "dropbox"
+		Type     string // ex: "local", "remote"
+		Start    time.Time
+		Duration time.Duration
+		Attempt  int  // 1 = first attempt
+		Final    bool // false for attempts followed by a retry
+		Err      string
+	}
+
+	Removal struct {
+		Destination string
+		Path        string
+		Size        int64
+		Time        time.Time
+	}
+)
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// RecordJob is safe to call on a nil Recorder
+func (r *Recorder) RecordJob(run JobRun) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jobs = append(r.jobs, run)
+}
+
+// RecordRemoval is safe to call on a nil Recorder
+func (r *Recorder) RecordRemoval(removal Removal) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.removals = append(r.removals, removal)
+}
+
+// Collect returns the entries recorded since the given time and discards older ones
+func (r *Recorder) Collect(since time.Time) ([]JobRun, []Removal) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	jobs := make([]JobRun, 0, len(r.jobs))
+	for _, j := range r.jobs {
+		if !j.Start.Before(since) {
+			jobs = append(jobs, j)
+		}
+	}
+
+	removals := make([]Removal, 0, len(r.removals))
+	for _, rm := range r.removals {
+		if !rm.Time.Before(since) {
+			removals = append(removals, rm)
+		}
+	}
+
+	r.jobs = jobs
+	r.removals = removals
+
+	return append([]JobRun(nil), jobs...), append([]Removal(nil), removals...)
+}
@@ -0,0 +1,114 @@
+package digest
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"text/template"
+	"time"
+
+	"github.com/BrunoTulio/pgopher/internal/utils"
+)
+
+var (
+	funcs = map[string]any{
+		"bytes": utils.FormatBytes,
+		"time": func(t time.Time) string {
+			if t.IsZero() {
+				return "-"
+			}
+			return utils.FormatTime(t)
+		},
+		"age": func(d time.Duration) string {
+			if d == 0 {
+				return "-"
+			}
+			return utils.FormatDuration(d)
+		},
+	}
+
+	textTemplate = template.Must(template.New("digest").Funcs(funcs).Parse(`pgopher report
+Period: {{time .From}} → {{time .To}}
+
+Jobs:
+{{- range .Jobs}}
+  • {{.Name}} ({{.Type}}): {{.Runs}} run(s), {{.Failures}} failure(s), {{.Retries}} retry(ies){{if .LastError}} — last error: {{.LastError}}{{end}}
+{{- else}}
+  (no jobs run)
+{{- end}}
+
+Destinations:
+{{- range .Destinations}}
+  • {{.Name}}: {{if .Err}}error: {{.Err}}{{else}}{{.Count}} backup(s), {{bytes .TotalBytes}}, newest {{time .Newest}} ({{age .NewestAge}} ago){{end}}
+{{- end}}
+
+Retention: {{len .Removals}} backup(s) removed, {{bytes .RemovedBytes}} freed
+{{- if .Warnings}}
+
+⚠️ Warnings:
+{{- range .Warnings}}
+  • {{.}}
+{{- end}}
+{{- end}}
+`))
+
+	htmlTemplate = htmltemplate.Must(htmltemplate.New("digest").Funcs(funcs).Parse(`<html>
+<body style="font-family: sans-serif">
+<h2>pgopher report</h2>
+<p>Period: {{time .From}} → {{time .To}}</p>
+{{- if .Warnings}}
+<h3>⚠️ Warnings</h3>
+<ul>
+{{- range .Warnings}}
+<li style="color: #b00020">{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+<h3>Jobs</h3>
+<table border="1" cellpadding="4" cellspacing="0">
+<tr><th>Job</th><th>Type</th><th>Runs</th><th>Failures</th><th>Retries</th><th>Last error</th></tr>
+{{- range .Jobs}}
+<tr><td>{{.Name}}</td><td>{{.Type}}</td><td>{{.Runs}}</td><td>{{.Failures}}</td><td>{{.Retries}}</td><td>{{.LastError}}</td></tr>
+{{- else}}
+<tr><td colspan="6">no jobs run</td></tr>
+{{- end}}
+</table>
+<h3>Destinations</h3>
+<table border="1" cellpadding="4" cellspacing="0">
+<tr><th>Destination</th><th>Backups</th><th>Storage</th><th>Newest</th><th>Age</th></tr>
+{{- range .Destinations}}
+{{- if .Err}}
+<tr><td>{{.Name}}</td><td colspan="4">error: {{.Err}}</td></tr>
+{{- else}}
+<tr><td>{{.Name}}</td><td>{{.Count}}</td><td>{{bytes .TotalBytes}}</td><td>{{time .Newest}}</td><td>{{age .NewestAge}}</td></tr>
+{{- end}}
+{{- end}}
+</table>
+<p>Retention: {{len .Removals}} backup(s) removed, {{bytes .RemovedBytes}} freed</p>
+</body>
+</html>
+`))
+)
+
+func (r Report) Subject() string {
+	if len(r.Warnings) > 0 {
+		return fmt.Sprintf("⚠️ pgopher report: %d warning(s)", len(r.Warnings))
+	}
+	return "✅ pgopher report: all good"
+}
+
+func (r Report) Text() (string, error) {
+	var buf bytes.Buffer
+	if err := textTemplate.Execute(&buf, r); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (r Report) HTML() (string, error) {
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, r); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,235 @@
+package export
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/pgopher/internal/catalog"
+	"github.com/BrunoTulio/pgopher/internal/encoder"
+	"github.com/BrunoTulio/pgopher/internal/utils"
+	"github.com/schollz/progressbar/v3"
+)
+
+// Exporter writes a backup of the catalog to a local path, optionally decrypted,
+// decompressed or converted to plain SQL, without touching any database
+type Exporter struct {
+	log     logr.Logger
+	opt     *Options
+	catalog *catalog.Catalog
+}
+
+func New(catalog *catalog.Catalog, log logr.Logger) *Exporter {
+	return NewWithOptions(catalog, log)
+}
+
+func NewWithOptions(catalog *catalog.Catalog, log logr.Logger, opts ...FnOptions) *Exporter {
+	opt := &Options{}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	return &Exporter{
+		log:     log,
+		opt:     opt,
+		catalog: catalog,
+	}
+}
+
+// Run exports the backup with the given ID from a provider to out, a directory (or "")
+// gets the backup name adjusted to the conversions. Returns the written file
+func (e *Exporter) Run(ctx context.Context, providerName, id, out string) (string, error) {
+	files, err := e.catalog.List(ctx, providerName)
+	if err != nil {
+		return "", fmt.Errorf("list catalog: %w", err)
+	}
+
+	var file catalog.BackupFile
+	for _, f := range files {
+		if f.Matches(id) {
+			file = f
+			break
+		}
+	}
+	if file.Name == "" {
+		return "", fmt.Errorf("backup %s not found in %s", id, providerName)
+	}
+
+	if e.opt.ToSQL && file.Physical {
+		return "", fmt.Errorf("%s is a physical backup, it has no SQL form", file.Name)
+	}
+	if e.opt.decrypt() && file.Encrypted && e.opt.EncryptionKey == "" {
+		return "", fmt.Errorf("backup is encrypted but no encryption key configured")
+	}
+
+	target, err := e.targetPath(file.Name, out)
+	if err != nil {
+		return "", err
+	}
+
+	e.log.Infof("📥 Exporting %s from %s to %s", file.Name, providerName, target)
+	start := time.Now()
+
+	source, err := e.catalog.Open(ctx, file)
+	if err != nil {
+		return "", fmt.Errorf("open %s: %w", file.Name, err)
+	}
+	defer func() {
+		_ = source.Close()
+	}()
+
+	bar := progressbar.DefaultBytes(file.Size, fmt.Sprintf("Downloading %s", file.Name))
+	reader, closeReader, err := e.convert(io.TeeReader(source, bar), file)
+	if err != nil {
+		return "", err
+	}
+	defer closeReader()
+
+	// written next to the target and renamed, an interrupted export leaves no partial file
+	partial := target + ".part"
+	defer func() {
+		_ = os.Remove(partial)
+	}()
+
+	if e.opt.ToSQL {
+		err = e.toSQL(ctx, reader, partial)
+	} else {
+		err = writeFile(reader, partial)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(partial, target); err != nil {
+		return "", fmt.Errorf("rename %s: %w", partial, err)
+	}
+
+	info, err := os.Stat(target)
+	if err != nil {
+		return "", err
+	}
+	e.log.Infof("✅ Exported %s (%s) in %s", target, utils.FormatBytes(info.Size()), time.Since(start).Round(time.Second))
+
+	return target, nil
+}
+
+// convert decrypts and decompresses the stream as requested
+func (e *Exporter) convert(input io.Reader, file catalog.BackupFile) (io.Reader, func(), error) {
+	reader := input
+	name := file.Name
+	closeReader := func() {}
+
+	if e.opt.decrypt() {
+		if file.Encrypted {
+			enc, err := encoder.NewEncryptor(e.opt.EncryptionKey)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create encryptor: %w", err)
+			}
+			reader, err = enc.DecryptReader(reader)
+			if err != nil {
+				return nil, nil, fmt.Errorf("decryption failed (wrong key?): %w", err)
+			}
+			name = strings.TrimSuffix(name, ".age")
+			e.log.Info("🔐 Decrypting (streaming)...")
+		} else if e.opt.Decrypt {
+			e.log.Info("🔓 Backup is not encrypted")
+		}
+	}
+
+	if e.opt.decompress() && strings.HasSuffix(name, ".gz") {
+		gzReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create gzip reader: %w", err)
+		}
+		reader = gzReader
+		closeReader = func() {
+			_ = gzReader.Close()
+		}
+		e.log.Info("📦 Decompressing (streaming)...")
+	}
+
+	return reader, closeReader, nil
+}
+
+// toSQL converts a pg_dump custom archive to a plain SQL script, owners and grants are left
+// out like on restore so the script loads into any database
+func (e *Exporter) toSQL(ctx context.Context, input io.Reader, target string) error {
+	e.log.Info("📝 Converting to SQL with pg_restore...")
+
+	cmd := exec.CommandContext(ctx, "pg_restore",
+		"--no-owner",
+		"--no-acl",
+		"-f", target,
+	)
+	cmd.Stdin = input
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("pg_restore failed: %w: %s", err, msg)
+		}
+		return fmt.Errorf("pg_restore failed: %w", err)
+	}
+	return nil
+}
+
+// targetPath returns out, or the adjusted backup name inside it when out is a directory
+func (e *Exporter) targetPath(backupName, out string) (string, error) {
+	if out == "" {
+		out = "."
+	}
+
+	info, err := os.Stat(out)
+	switch {
+	case err == nil && info.IsDir():
+		return filepath.Join(out, e.outputName(backupName)), nil
+	case err == nil || os.IsNotExist(err):
+		return out, nil
+	default:
+		return "", fmt.Errorf("output %s: %w", out, err)
+	}
+}
+
+// outputName drops the extensions of the removed layers, ex: app-20261001-030000.sql.gz.age
+// is app-20261001-030000.sql.gz decrypted, .dump decompressed and .sql converted
+func (e *Exporter) outputName(name string) string {
+	if e.opt.decrypt() {
+		name = strings.TrimSuffix(name, ".age")
+	}
+	if e.opt.decompress() {
+		name = strings.TrimSuffix(name, ".gz")
+	}
+
+	base := strings.TrimSuffix(name, ".sql")
+	switch {
+	case e.opt.ToSQL:
+		return base + ".sql"
+	case e.opt.decompress() && base != name:
+		return base + ".dump" // pg_dump custom archive, not SQL
+	default:
+		return name
+	}
+}
+
+func writeFile(input io.Reader, target string) error {
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", target, err)
+	}
+
+	if _, err := io.Copy(f, input); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("write %s: %w", target, err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,46 @@
+package export
+
+import "github.com/BrunoTulio/pgopher/internal/config"
+
+type (
+	FnOptions func(*Options)
+
+	Options struct {
+		EncryptionKey string
+		Decrypt       bool // remove the age encryption
+		Decompress    bool // remove the gzip compression, implies Decrypt
+		ToSQL         bool // convert the pg_dump archive to plain SQL with pg_restore -f, implies Decompress
+	}
+)
+
+func WithConfig(cfg *config.Config) FnOptions {
+	return func(opt *Options) {
+		opt.EncryptionKey = cfg.EncryptionKey
+	}
+}
+
+func WithDecrypt(decrypt bool) FnOptions {
+	return func(opt *Options) {
+		opt.Decrypt = decrypt
+	}
+}
+
+func WithDecompress(decompress bool) FnOptions {
+	return func(opt *Options) {
+		opt.Decompress = decompress
+	}
+}
+
+func WithToSQL(toSQL bool) FnOptions {
+	return func(opt *Options) {
+		opt.ToSQL = toSQL
+	}
+}
+
+func (o *Options) decrypt() bool {
+	return o.Decrypt || o.decompress()
+}
+
+func (o *Options) decompress() bool {
+	return o.Decompress || o.ToSQL
+}
@@ -0,0 +1,168 @@
+package heartbeat
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/pgopher/internal/config"
+	"github.com/BrunoTulio/pgopher/internal/utils"
+)
+
+const (
+	KindHealthchecks = "healthchecks"
+	KindUptimeKuma   = "uptime_kuma"
+
+	defaultTimeout = 10 * time.Second
+	maxBodySize    = 10 * 1024 // healthchecks.io keeps at most 10 KB of the body
+)
+
+// Heartbeat sends dead-man's-switch pings around a job, so an external monitor
+// alerts when an expected backup never happens.
+type Heartbeat struct {
+	name   string
+	cfg    config.HeartbeatConfig
+	client *http.Client
+	log    logr.Logger
+}
+
+func New(name string, cfg config.HeartbeatConfig, log logr.Logger) *Heartbeat {
+	timeout := defaultTimeout
+	if cfg.Timeout > 0 {
+		timeout = time.Duration(cfg.Timeout) * time.Second
+	}
+
+	return &Heartbeat{
+		name:   name,
+		cfg:    cfg,
+		client: &http.Client{Timeout: timeout},
+		log:    log,
+	}
+}
+
+func (h *Heartbeat) IsEnabled() bool {
+	return h.cfg.IsEnabled()
+}
+
+// Start signals that the job has started (healthchecks.io measures run time from it)
+func (h *Heartbeat) Start(ctx context.Context) {
+	h.ping(ctx, "start", h.startURL(), "")
+}
+
+// Success signals that the job finished successfully
+func (h *Heartbeat) Success(ctx context.Context, msg string) {
+	h.ping(ctx, "success", h.successURL(msg), msg)
+}
+
+// Fail signals that the job failed, so the monitor alerts immediately
+func (h *Heartbeat) Fail(ctx context.Context, errMsg string) {
+	h.ping(ctx, "fail", h.failURL(errMsg), errMsg)
+}
+
+func (h *Heartbeat) ping(ctx context.Context, event, target, body string) {
+	if target == "" {
+		return
+	}
+
+	body = utils.Truncate(body, maxBodySize)
+
+	method := http.MethodPost
+	if h.isUptimeKuma() {
+		// Uptime Kuma push monitors read status and message from the query string
+		method = http.MethodGet
+		body = ""
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewBufferString(body))
+	if err != nil {
+		h.log.Warnf("💓 Heartbeat %s (%s) request failed: %v", event, h.name, err)
+		return
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	req.Header.Set("User-Agent", "pgopher-backup/1.0")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		h.log.Warnf("💓 Heartbeat %s (%s) failed: %v", event, h.name, err)
+		return
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 300 {
+		h.log.Warnf("💓 Heartbeat %s (%s) returned status %d", event, h.name, resp.StatusCode)
+		return
+	}
+
+	h.log.Debugf("💓 Heartbeat %s (%s) sent", event, h.name)
+}
+
+func (h *Heartbeat) startURL() string {
+	if h.cfg.StartURL != "" {
+		return h.cfg.StartURL
+	}
+	if h.cfg.URL == "" || h.isUptimeKuma() {
+		// Uptime Kuma push monitors have no "start" signal
+		return ""
+	}
+	return joinPath(h.cfg.URL, "start")
+}
+
+func (h *Heartbeat) successURL(msg string) string {
+	if h.cfg.SuccessURL != "" {
+		return h.cfg.SuccessURL
+	}
+	if h.cfg.URL == "" {
+		return ""
+	}
+	if h.isUptimeKuma() {
+		return withQuery(h.cfg.URL, "up", msg)
+	}
+	return h.cfg.URL
+}
+
+func (h *Heartbeat) failURL(errMsg string) string {
+	if h.cfg.FailURL != "" {
+		return h.cfg.FailURL
+	}
+	if h.cfg.URL == "" {
+		return ""
+	}
+	if h.isUptimeKuma() {
+		return withQuery(h.cfg.URL, "down", errMsg)
+	}
+	return joinPath(h.cfg.URL, "fail")
+}
+
+func (h *Heartbeat) isUptimeKuma() bool {
+	return strings.ToLower(h.cfg.Kind) == KindUptimeKuma
+}
+
+func joinPath(base, suffix string) string {
+	u, err := url.Parse(base)
+	if err != nil {
+		return strings.TrimSuffix(base, "/") + "/" + suffix
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + suffix
+	return u.String()
+}
+
+func withQuery(base, status, msg string) string {
+	u, err := url.Parse(base)
+	if err != nil {
+		return base
+	}
+
+	msg = utils.Truncate(msg, 250)
+
+	q := u.Query()
+	q.Set("status", status)
+	q.Set("msg", msg)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
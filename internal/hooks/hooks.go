@@ -0,0 +1,239 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/pgopher/internal/config"
+	"github.com/BrunoTulio/pgopher/internal/database"
+)
+
+type Event string
+
+const (
+	PreBackup   Event = "pre_backup"
+	PostBackup  Event = "post_backup"
+	OnFailure   Event = "on_failure"
+	PreRestore  Event = "pre_restore"
+	PostRestore Event = "post_restore"
+
+	defaultTimeout = 60 * time.Second
+
+	// waitDelay bounds the wait for the output of processes a hook left running
+	waitDelay = 5 * time.Second
+
+	maxOutputLine = 64 * 1024
+)
+
+type (
+	// Runner runs the configured hooks, its methods are safe to call on a nil Runner
+	Runner struct {
+		cfg      config.HooksConfig
+		database config.DatabaseConfig
+		log      logr.Logger
+	}
+
+	// outputLogger logs the output of a hook line by line
+	outputLogger struct {
+		log logr.Logger
+		buf []byte
+	}
+
+	// Job describes the job a hook runs for, exported to commands as PGOPHER_* env vars
+	Job struct {
+		Name     string // "local", provider name or "restore"
+		Type     string // "local", "remote" or "restore"
+		File     string // backup file, local path or remote object
+		Size     int64
+		Provider string
+		Database config.DatabaseConfig // overrides the runner database when set
+		Err      error
+	}
+)
+
+func New(cfg config.HooksConfig, database config.DatabaseConfig, log logr.Logger) *Runner {
+	if !cfg.IsEnabled() {
+		return nil
+	}
+
+	return &Runner{
+		cfg:      cfg,
+		database: database,
+		log:      log,
+	}
+}
+
+// Run runs the hooks of an event in order. A failing hook with on_error "abort"
+// (the default for pre hooks) stops the remaining hooks and its error is returned.
+func (r *Runner) Run(ctx context.Context, event Event, job Job) error {
+	if r == nil {
+		return nil
+	}
+
+	for i, hook := range r.hooks(event) {
+		if !matches(hook, job) {
+			continue
+		}
+
+		name := hook.Name
+		if name == "" {
+			name = fmt.Sprintf("%s[%d]", event, i)
+		}
+
+		r.log.Infof("🪝 Running hook %s (%s)", name, job.Name)
+		start := time.Now()
+
+		if err := r.runHook(ctx, hook, event, job); err != nil {
+			if abort(hook, event) {
+				return fmt.Errorf("hook %s: %w", name, err)
+			}
+			r.log.Warnf("⚠️  Hook %s failed, continuing: %v", name, err)
+			continue
+		}
+
+		r.log.Infof("✅ Hook %s done in %s", name, time.Since(start).Round(time.Millisecond))
+	}
+
+	return nil
+}
+
+func (r *Runner) hooks(event Event) []config.HookConfig {
+	switch event {
+	case PreBackup:
+		return r.cfg.PreBackup
+	case PostBackup:
+		return r.cfg.PostBackup
+	case OnFailure:
+		return r.cfg.OnFailure
+	case PreRestore:
+		return r.cfg.PreRestore
+	case PostRestore:
+		return r.cfg.PostRestore
+	default:
+		return nil
+	}
+}
+
+func (r *Runner) runHook(ctx context.Context, hook config.HookConfig, event Event, job Job) error {
+	timeout := defaultTimeout
+	if hook.Timeout > 0 {
+		timeout = time.Duration(hook.Timeout) * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if hook.SQL != "" {
+		db := r.databaseFor(job)
+		return database.NewClient(&db).Exec(ctx, hook.SQL)
+	}
+
+	return r.runCommand(ctx, hook.Command, r.env(event, job))
+}
+
+func (r *Runner) databaseFor(job Job) config.DatabaseConfig {
+	if job.Database.Name != "" {
+		return job.Database
+	}
+	return r.database
+}
+
+// runCommand runs a shell command in its own process group, the timeout kills the shell
+// and everything it started. The output is logged while it runs, a background process
+// keeping it open is waited for at most waitDelay once the shell exits
+func (r *Runner) runCommand(ctx context.Context, command string, env []string) error {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Env = append(os.Environ(), env...)
+	killProcessGroup(cmd)
+	cmd.WaitDelay = waitDelay
+
+	output := &outputLogger{log: r.log}
+	cmd.Stdout = output
+	cmd.Stderr = output // the same writer, exec never calls it concurrently
+	defer output.flush()
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start: %w", err)
+	}
+
+	err := cmd.Wait()
+	switch {
+	case errors.Is(err, exec.ErrWaitDelay):
+		// the shell succeeded, only a background process it started is still running
+		r.log.Warnf("⚠️  Hook exited but left a process holding its output, output no longer logged")
+		return nil
+	case err != nil && ctx.Err() != nil:
+		return fmt.Errorf("timed out: %w", ctx.Err())
+	default:
+		return err
+	}
+}
+
+func (o *outputLogger) Write(p []byte) (int, error) {
+	o.buf = append(o.buf, p...)
+	for {
+		i := bytes.IndexByte(o.buf, '\n')
+		if i < 0 {
+			break
+		}
+		o.log.Infof("hook: %s", o.buf[:i])
+		o.buf = o.buf[i+1:]
+	}
+	if len(o.buf) > maxOutputLine {
+		o.flush()
+	}
+	return len(p), nil
+}
+
+func (o *outputLogger) flush() {
+	if len(o.buf) > 0 {
+		o.log.Infof("hook: %s", o.buf)
+		o.buf = nil
+	}
+}
+
+func (r *Runner) env(event Event, job Job) []string {
+	vars := []string{
+		"PGOPHER_EVENT=" + string(event),
+		"PGOPHER_JOB=" + job.Name,
+		"PGOPHER_JOB_TYPE=" + job.Type,
+		"PGOPHER_FILE=" + job.File,
+		"PGOPHER_FILE_SIZE=" + strconv.FormatInt(job.Size, 10),
+		"PGOPHER_PROVIDER=" + job.Provider,
+		"PGOPHER_DATABASE=" + r.databaseFor(job).Name,
+	}
+	if job.Err != nil {
+		vars = append(vars, "PGOPHER_ERROR="+job.Err.Error())
+	}
+	return vars
+}
+
+func matches(hook config.HookConfig, job Job) bool {
+	if len(hook.Only) == 0 {
+		return true
+	}
+	for _, name := range hook.Only {
+		if name == job.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func abort(hook config.HookConfig, event Event) bool {
+	switch hook.OnError {
+	case "abort":
+		return true
+	case "continue":
+		return false
+	default:
+		return event == PreBackup || event == PreRestore
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package hooks
+
+import "os/exec"
+
+// killProcessGroup keeps the default of exec.CommandContext, only the shell is killed
+func killProcessGroup(cmd *exec.Cmd) {}
@@ -27,6 +27,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	mux.HandleFunc("GET /health", s.handleHealth)
 	mux.HandleFunc("GET /status", s.handleStatus)
 	mux.HandleFunc("GET /providers", s.handleProviders)
+	mux.HandleFunc("GET /catalog", s.handleCatalog)
 	mux.HandleFunc("GET /catalog/{provider}", s.handleCatalogProvider)
 
 	mux.ServeHTTP(w, r)
@@ -43,11 +44,13 @@ type ProvidersResponse struct {
 }
 
 type JobStatus struct {
-	Name     string `json:"name"`
-	Type     string `json:"type"`
-	Schedule string `json:"schedule"`
-	Next     string `json:"next"`
-	Prev     string `json:"prev"`
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Schedule string   `json:"schedule"`
+	Cron     string   `json:"cron"`
+	Next     string   `json:"next"`
+	Prev     string   `json:"prev"`
+	NextRuns []string `json:"next_runs"`
 }
 
 func New(
@@ -74,12 +77,19 @@ func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
 
 	out := make([]JobStatus, 0, len(jobs))
 	for _, j := range jobs {
+		nextRuns := make([]string, 0, len(j.NextRuns))
+		for _, next := range j.NextRuns {
+			nextRuns = append(nextRuns, next.Format(time.RFC3339))
+		}
+
 		out = append(out, JobStatus{
 			Name:     j.Name,
 			Type:     j.Type,
 			Schedule: j.Schedule,
+			Cron:     j.CronExpr,
 			Next:     j.Next.Format(time.RFC3339),
 			Prev:     j.Prev.Format(time.RFC3339),
+			NextRuns: nextRuns,
 		})
 	}
 
@@ -107,6 +117,22 @@ func (s *Server) handleProviders(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// handleCatalog returns every backup with the destinations holding a copy of it
+func (s *Server) handleCatalog(w http.ResponseWriter, r *http.Request) {
+	view := s.catalogSrv.View(r.Context())
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(struct {
+		catalog.View
+		Count     int    `json:"count"`
+		Timestamp string `json:"timestamp"`
+	}{
+		View:      view,
+		Count:     len(view.Backups),
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
 func (s *Server) handleCatalogProvider(w http.ResponseWriter, r *http.Request) {
 	providers := []string{"local"}
 	for _, p := range s.config.RemoteProviders {
@@ -134,12 +160,14 @@ func (s *Server) handleCatalogProvider(w http.ResponseWriter, r *http.Request) {
 	filesResp := make([]map[string]any, len(files))
 	for i, file := range files {
 		filesResp[i] = map[string]any{
+			"id":         file.ID,
 			"short_id":   file.ShortID,
 			"name":       file.Name,
 			"size_bytes": file.Size,
 			"size_human": utils.FormatBytes(file.Size),
 			"mod_time":   file.ModTime,
 			"encrypted":  file.Encrypted,
+			"physical":   file.Physical,
 		}
 	}
 
@@ -0,0 +1,109 @@
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ext is appended to a backup name to get its manifest, ex: app-20261001-030000.sql.gz.manifest.json.
+// The manifest travels with the backup (upload, copy) so its ID is the same everywhere
+const Ext = ".manifest.json"
+
+type Manifest struct {
+	ID        string    `json:"id"`
+	Database  string    `json:"database"`
+	Method    string    `json:"method"`
+	Size      int64     `json:"size"`
+	SHA256    string    `json:"sha256"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Name returns the manifest name of a backup
+func Name(backupName string) string {
+	return backupName + Ext
+}
+
+func IsManifest(name string) bool {
+	return strings.HasSuffix(name, Ext)
+}
+
+// Create gives a backup file a new ID and writes its manifest next to it
+func Create(backupPath, database, method string) (*Manifest, error) {
+	f, err := os.Open(backupPath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return nil, fmt.Errorf("checksum %s: %w", backupPath, err)
+	}
+
+	m := &Manifest{
+		ID:        uuid.NewString(),
+		Database:  database,
+		Method:    method,
+		Size:      size,
+		SHA256:    hex.EncodeToString(h.Sum(nil)),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(Name(backupPath), data, 0o600); err != nil {
+		return nil, fmt.Errorf("write manifest: %w", err)
+	}
+
+	return m, nil
+}
+
+func Read(r io.Reader) (*Manifest, error) {
+	var m Manifest
+	if err := json.NewDecoder(io.LimitReader(r, 64*1024)).Decode(&m); err != nil {
+		return nil, fmt.Errorf("decode manifest: %w", err)
+	}
+	if m.ID == "" {
+		return nil, fmt.Errorf("manifest without id")
+	}
+	return &m, nil
+}
+
+// ReadFile reads the manifest of a local backup
+func ReadFile(backupPath string) (*Manifest, error) {
+	f, err := os.Open(Name(backupPath))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return Read(f)
+}
+
+// ShortID is the first 8 hex digits of the ID, shown by the CLI and accepted by --id
+func (m *Manifest) ShortID() string {
+	return ShortID(m.ID)
+}
+
+func ShortID(id string) string {
+	hexID := strings.ReplaceAll(id, "-", "")
+	if len(hexID) < 8 {
+		return hexID
+	}
+	return hexID[:8]
+}
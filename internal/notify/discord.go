@@ -9,8 +9,11 @@ import (
 	"time"
 
 	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/pgopher/internal/utils"
 )
 
+const discordMaxContent = 2000
+
 type DiscordNotifier struct {
 	webhookURL string
 	log        logr.Logger
@@ -27,6 +30,14 @@ func (d *DiscordNotifier) Error(ctx context.Context, errMsg string) error {
 	return d.send(ctx, errMsg)
 }
 
+func (d *DiscordNotifier) Report(ctx context.Context, report Report) error {
+	// only the text is cut, the code block around it stays closed
+	header := fmt.Sprintf("📊 **%s**\n```\n", report.Subject)
+	footer := "\n```"
+	msg := header + utils.Truncate(report.Text, discordMaxContent-len(header)-len(footer)) + footer
+	return d.send(ctx, msg)
+}
+
 func (d *DiscordNotifier) send(ctx context.Context, msg string) error {
 	type Payload struct {
 		Content string `json:"content"`
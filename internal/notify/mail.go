@@ -35,6 +35,19 @@ func (m *MailNotifier) Error(ctx context.Context, errMsg string) error {
 	return m.sendEmail(ctx, subject, body)
 }
 
+func (m *MailNotifier) Report(ctx context.Context, report Report) error {
+	ms, err := m.toMessage(report.Subject, report.Text)
+	if err != nil {
+		return fmt.Errorf("toMessage: %w", err)
+	}
+
+	if report.HTML != "" {
+		ms.AddAlternativeString(mail.TypeTextHTML, report.HTML)
+	}
+
+	return m.send(ctx, ms)
+}
+
 func NewMail(
 	smtpHost string,
 	smtpPort int,
@@ -64,6 +77,10 @@ func (m *MailNotifier) sendEmail(ctx context.Context, subject, body string) erro
 		return fmt.Errorf("toMessage: %w", err)
 	}
 
+	return m.send(ctx, ms)
+}
+
+func (m *MailNotifier) send(ctx context.Context, ms *mail.Msg) error {
 	client, err := mail.NewClient(
 		m.smtpHost,
 		mail.WithPort(m.smtpPort),
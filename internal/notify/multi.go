@@ -58,6 +58,22 @@ func (m *MultiNotifier) Error(ctx context.Context, errMsg string) error {
 	return nil
 }
 
+// Report is always delivered; digests are enabled on their own
+func (m *MultiNotifier) Report(ctx context.Context, report Report) error {
+	var errs []error
+	for _, n := range m.notifiers {
+		if err := n.Report(ctx, report); err != nil {
+			errs = append(errs, err)
+			m.log.Warnf("Notifier sendReport failed: %v", err)
+		}
+	}
+	if len(errs) > 0 && len(errs) == len(m.notifiers) {
+		return fmt.Errorf("all notifiers failed: %v", errs)
+	}
+
+	return nil
+}
+
 func NewMultiNotifier(
 	enablesSuccess bool,
 	enabledError bool,
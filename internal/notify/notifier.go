@@ -5,4 +5,12 @@ import "context"
 type Notifier interface {
 	Success(ctx context.Context, msg string) error
 	Error(ctx context.Context, errMsg string) error
+	Report(ctx context.Context, report Report) error
+}
+
+// Report is a periodic summary message; HTML is used by notifiers that support it
+type Report struct {
+	Subject string
+	Text    string
+	HTML    string
 }
@@ -9,8 +9,11 @@ import (
 	"time"
 
 	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/pgopher/internal/utils"
 )
 
+const telegramMaxText = 4096
+
 type TelegramNotifier struct {
 	botToken string
 	chatID   string
@@ -28,6 +31,13 @@ func (t *TelegramNotifier) Error(ctx context.Context, errMsg string) error {
 	return t.sendMessage(ctx, text)
 }
 
+func (t *TelegramNotifier) Report(ctx context.Context, report Report) error {
+	// only the body is cut, the header stays whole
+	header := fmt.Sprintf("📊 %s\n\n", report.Subject)
+	text := header + utils.Truncate(report.Text, telegramMaxText-len(header))
+	return t.sendMessage(ctx, text)
+}
+
 func (t *TelegramNotifier) sendMessage(ctx context.Context, text string) error {
 	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", t.botToken)
 
@@ -0,0 +1,132 @@
+package remote
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/rclone/rclone/fs"
+	"golang.org/x/time/rate"
+)
+
+// rclone evaluates --bwlimit-file once, when a transfer starts, so a timetable would not
+// apply to a dump uploading across a slot change. The provider streams go through its own
+// token bucket instead, the timetable is looked up again on every read
+var (
+	bandwidthMu sync.Mutex
+	bandwidths  = map[string]*bandwidth{} // provider name -> bucket shared by its instances
+)
+
+type (
+	bandwidth struct {
+		setting   string
+		timetable fs.BwTimetable
+		tx        *rate.Limiter // uploads
+		rx        *rate.Limiter // downloads
+	}
+
+	throttledReader struct {
+		ctx    context.Context
+		in     io.ReadCloser
+		bw     *bandwidth
+		upload bool
+	}
+
+	// throttledObject streams the object through the bucket of the destination when rclone
+	// copies it between providers
+	throttledObject struct {
+		fs.Object
+		bw *bandwidth
+	}
+)
+
+// bandwidthFor returns the bucket of a provider, nil without limit. Instances of the same
+// provider (scheduled backup, replication, restore) share it, the limit is per provider
+func bandwidthFor(name, setting string) (*bandwidth, error) {
+	if setting == "" {
+		return nil, nil
+	}
+
+	bandwidthMu.Lock()
+	defer bandwidthMu.Unlock()
+
+	if bw, ok := bandwidths[name]; ok && bw.setting == setting {
+		return bw, nil
+	}
+
+	var timetable fs.BwTimetable
+	if err := timetable.Set(setting); err != nil {
+		return nil, err
+	}
+
+	bw := &bandwidth{
+		setting:   setting,
+		timetable: timetable,
+		tx:        rate.NewLimiter(rate.Inf, 0),
+		rx:        rate.NewLimiter(rate.Inf, 0),
+	}
+	bandwidths[name] = bw
+	return bw, nil
+}
+
+// limiter returns the bucket for the current slot of the timetable, nil when it is off
+func (b *bandwidth) limiter(upload bool) *rate.Limiter {
+	slot := b.timetable.LimitAt(time.Now()).Bandwidth
+	limit, l := slot.Rx, b.rx
+	if upload {
+		limit, l = slot.Tx, b.tx
+	}
+	if limit <= 0 {
+		return nil
+	}
+
+	if l.Limit() != rate.Limit(limit) {
+		// one second of traffic, a read never waits for more than that
+		l.SetLimit(rate.Limit(limit))
+		l.SetBurst(int(limit))
+	}
+	return l
+}
+
+// reader throttles a stream of the provider, in is returned as is without limit
+func (b *bandwidth) reader(ctx context.Context, in io.ReadCloser, upload bool) io.ReadCloser {
+	if b == nil {
+		return in
+	}
+	return &throttledReader{ctx: ctx, in: in, bw: b, upload: upload}
+}
+
+func (r *throttledReader) Read(p []byte) (int, error) {
+	l := r.bw.limiter(r.upload)
+	if l == nil {
+		return r.in.Read(p)
+	}
+
+	if burst := l.Burst(); len(p) > burst {
+		p = p[:burst]
+	}
+	n, err := r.in.Read(p)
+
+	// the slot may change while waiting, WaitN fails above the burst
+	for remaining := n; remaining > 0; {
+		wait := min(remaining, max(l.Burst(), 1))
+		if werr := l.WaitN(r.ctx, wait); werr != nil {
+			return n, werr
+		}
+		remaining -= wait
+	}
+	return n, err
+}
+
+func (r *throttledReader) Close() error {
+	return r.in.Close()
+}
+
+func (o throttledObject) Open(ctx context.Context, options ...fs.OpenOption) (io.ReadCloser, error) {
+	in, err := o.Object.Open(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+	return o.bw.reader(ctx, in, true), nil
+}
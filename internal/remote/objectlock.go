@@ -0,0 +1,147 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/rclone/rclone/fs/fshttp"
+)
+
+// ErrObjectLocked is returned by Delete while the retention or legal hold of a file is active
+var ErrObjectLocked = errors.New("object is locked")
+
+// lock applies the provider retention and legal hold to an uploaded file, rclone does not
+// send the object lock headers on upload so they are set with the S3 API right after it
+func (p *Provider) lock(ctx context.Context, fullPath string) error {
+	client, err := p.s3Client(ctx)
+	if err != nil {
+		return err
+	}
+	bucket, key := splitBucket(fullPath)
+	lock := p.opt.ObjectLock
+
+	if lock.Mode != "" {
+		until := time.Now().AddDate(0, 0, lock.Days)
+		_, err := client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+			Retention: &types.ObjectLockRetention{
+				Mode:            types.ObjectLockRetentionMode(strings.ToUpper(lock.Mode)),
+				RetainUntilDate: aws.Time(until),
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("set retention: %w", err)
+		}
+		p.log.Infof("   🔒 Locked (%s) until %s", strings.ToLower(lock.Mode), until.Format(time.DateTime))
+	}
+
+	if lock.LegalHold {
+		_, err := client.PutObjectLegalHold(ctx, &s3.PutObjectLegalHoldInput{
+			Bucket:    aws.String(bucket),
+			Key:       aws.String(key),
+			LegalHold: &types.ObjectLockLegalHold{Status: types.ObjectLockLegalHoldStatusOn},
+		})
+		if err != nil {
+			return fmt.Errorf("set legal hold: %w", err)
+		}
+		p.log.Infof("   🔒 Legal hold on")
+	}
+
+	return nil
+}
+
+// checkLock returns ErrObjectLocked when the current version of a file is under retention
+// or legal hold
+func (p *Provider) checkLock(ctx context.Context, fullPath string) error {
+	client, err := p.s3Client(ctx)
+	if err != nil {
+		return err
+	}
+	bucket, key := splitBucket(fullPath)
+
+	head, err := client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("head %s: %w", fullPath, err)
+	}
+
+	if head.ObjectLockLegalHoldStatus == types.ObjectLockLegalHoldStatusOn {
+		return fmt.Errorf("%s: %w by a legal hold", fullPath, ErrObjectLocked)
+	}
+	if head.ObjectLockRetainUntilDate != nil && head.ObjectLockRetainUntilDate.After(time.Now()) {
+		return fmt.Errorf("%s: %w until %s", fullPath, ErrObjectLocked,
+			head.ObjectLockRetainUntilDate.Local().Format(time.DateTime))
+	}
+
+	return nil
+}
+
+// s3Client connects to the bucket with the credentials and endpoint of the provider config,
+// the same way the rclone s3 backend does
+func (p *Provider) s3Client(ctx context.Context) (*s3.Client, error) {
+	cfg := p.opt.Config
+
+	region := configValue(cfg, "region")
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	loadOpts := []func(*awsconfig.LoadOptions) error{
+		awsconfig.WithRegion(region),
+		awsconfig.WithHTTPClient(fshttp.NewClient(p.withConfig(ctx))),
+	}
+	if accessKey := configValue(cfg, "access_key_id"); accessKey != "" {
+		loadOpts = append(loadOpts, awsconfig.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
+			accessKey, configValue(cfg, "secret_access_key"), configValue(cfg, "session_token"))))
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, loadOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("load s3 config: %w", err)
+	}
+
+	// rclone uses path-style requests except for AWS
+	pathStyle := !strings.EqualFold(configValue(cfg, "provider"), "AWS")
+	if forcePathStyle, err := strconv.ParseBool(configValue(cfg, "force_path_style")); err == nil {
+		pathStyle = forcePathStyle
+	}
+
+	endpoint := configValue(cfg, "endpoint")
+	if endpoint != "" && !strings.HasPrefix(endpoint, "http") {
+		endpoint = "https://" + endpoint
+	}
+
+	return s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		o.UsePathStyle = pathStyle
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+	}), nil
+}
+
+// splitBucket splits a path of the s3 remote root into bucket and key
+func splitBucket(fullPath string) (string, string) {
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(fullPath, "/"), "/")
+	return bucket, key
+}
+
+func configValue(config map[string]string, name string) string {
+	for key, value := range config {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
@@ -2,10 +2,9 @@ package remote
 
 import (
 	"fmt"
-	"os"
-	"strings"
 
 	"github.com/BrunoTulio/pgopher/internal/config"
+	"github.com/BrunoTulio/pgopher/internal/hooks"
 )
 
 type (
@@ -13,12 +12,19 @@ type (
 
 	Options struct {
 		Name          string
-		Type          string // s3, drive, dropbox, mega
+		Type          string // any rclone backend: s3, drive, sftp, azureblob, b2, webdav, local...
 		Path          string // prefixo remoto: bucket/pasta/base
 		MaxVersions   int    // 0 = sobrescreve, >0 = rotaciona versões
 		Config        map[string]string
+		Remote        string // named remote of an rclone.conf (ex: "crypt-backups"), replaces Type/Config
+		RcloneConfig  string // rclone.conf holding Remote, default rclone's own config path
+		Rclone        config.RcloneTuning
+		ObjectLock    config.ObjectLockConfig // retention and legal hold of s3 uploads
 		Database      config.DatabaseConfig
 		EncryptionKey string
+		Hooks         *hooks.Runner // pre/post backup and failure hooks, nil = none
+		Method        string        // config.MethodLogical (default) or config.MethodPhysical
+		WorkDir       string        // where the backup is generated before the upload, empty = system temp dir
 	}
 )
 
@@ -29,12 +35,29 @@ func WithOptions(cfg config.RemoteProvider, database config.DatabaseConfig, encr
 		opt.Path = cfg.Path
 		opt.MaxVersions = cfg.MaxVersions
 		opt.Config = cfg.Config
+		opt.Remote = cfg.Remote
+		opt.RcloneConfig = cfg.RcloneConfig
+		opt.Rclone = cfg.Rclone
+		opt.ObjectLock = cfg.ObjectLock
 		opt.Database = database
 		opt.EncryptionKey = encryptionKey
+		opt.Method = cfg.Method
 
 	}
 }
 
+func WithHooks(runner *hooks.Runner) FnOptions {
+	return func(opts *Options) {
+		opts.Hooks = runner
+	}
+}
+
+func WithWorkDir(dir string) FnOptions {
+	return func(opts *Options) {
+		opts.WorkDir = dir
+	}
+}
+
 func WithMaxVersions(maxVersions int) FnOptions {
 	return func(opts *Options) {
 		opts.MaxVersions = maxVersions
@@ -53,16 +76,21 @@ func (o *Options) HasVersioning() bool {
 
 // GetRemoteFileName gera nome do arquivo baseado na estratégia
 func (o *Options) GetRemoteFileName(currentVersion int) string {
+	name := o.Database.Name
 	ext := ".sql.gz"
+	if config.IsPhysical(o.Method) {
+		name += "-physical"
+		ext = ".tar.gz"
+	}
 	if o.EncryptionKey != "" {
 		ext += ".age"
 	}
 
 	if o.HasVersioning() {
-		return fmt.Sprintf("%s-v%d%s", o.Database.Name, currentVersion, ext)
+		return fmt.Sprintf("%s-v%d%s", name, currentVersion, ext)
 	}
 
-	return fmt.Sprintf("%s%s", o.Database.Name, ext)
+	return fmt.Sprintf("%s%s", name, ext)
 }
 
 func (o *Options) GetRcloneRemotePath() string {
@@ -75,39 +103,3 @@ func (o *Options) RemotePathFor(fileName string) string {
 	}
 	return fmt.Sprintf("%s/%s", o.Path, fileName)
 }
-
-func (o *Options) CleanupEnv() {
-	envPrefix := o.EnvPrefix()
-
-	_ = os.Unsetenv(envPrefix + "TYPE")
-	for k := range o.Config {
-		_ = os.Unsetenv(envPrefix + strings.ToUpper(k))
-	}
-}
-
-func (o *Options) EnvPrefix() string {
-	return fmt.Sprintf("RCLONE_CONFIG_%s_", strings.ToUpper(o.Name))
-
-}
-
-func (o *Options) SetupEnv() error {
-	envPrefix := o.EnvPrefix()
-
-	if err := os.Setenv(envPrefix+"TYPE", o.Type); err != nil {
-		o.CleanupEnv() // 👈 LIMPA se erro
-
-		return fmt.Errorf("set TYPE env: %w", err)
-	}
-
-	for k, v := range o.Config {
-		envKey := envPrefix + strings.ToUpper(k)
-		if err := os.Setenv(envKey, v); err != nil {
-			o.CleanupEnv() // 👈 LIMPA se erro
-
-			return fmt.Errorf("set %s env: %w", envKey, err)
-		}
-	}
-
-	return nil
-
-}
@@ -2,24 +2,26 @@ package remote
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/BrunoTulio/logr"
 	"github.com/BrunoTulio/pgopher/internal/backup"
+	"github.com/BrunoTulio/pgopher/internal/hooks"
+	"github.com/BrunoTulio/pgopher/internal/manifest"
 	"github.com/BrunoTulio/pgopher/internal/utils"
 	"github.com/rclone/rclone/fs"
 	"github.com/rclone/rclone/fs/operations"
 	"github.com/schollz/progressbar/v3"
 
-	// Backends
-	_ "github.com/rclone/rclone/backend/drive"
-	_ "github.com/rclone/rclone/backend/dropbox"
-	_ "github.com/rclone/rclone/backend/mega"
-	_ "github.com/rclone/rclone/backend/s3"
+	// Every rclone backend, Options.Type is the rclone remote type
+	_ "github.com/rclone/rclone/backend/all"
 )
 
 var (
@@ -31,14 +33,17 @@ type (
 		log            logr.Logger
 		opt            *Options
 		fsys           fs.Fs
+		ci             *fs.ConfigInfo // global rclone config with the provider tuning
+		bw             *bandwidth     // bwlimit of the provider, nil without limit
 		currentVersion int
 	}
 
 	BackupFile struct {
-		Name    string
-		Path    string
-		ModTime time.Time
-		Size    int64
+		Name     string
+		Path     string
+		ModTime  time.Time
+		Size     int64
+		Manifest bool // a manifest was uploaded with the backup, see manifest.Name
 	}
 )
 
@@ -58,27 +63,59 @@ func NewProviderWithOptions(
 		o(opt)
 	}
 
-	fsys, err := createRemoteFs(opt)
+	ci, err := tunedConfig(opt.Rclone)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create remote filesystem: %w", err)
+		return nil, fmt.Errorf("rclone tuning: %w", err)
+	}
+	bw, err := bandwidthFor(opt.Name, opt.Rclone.BwLimit)
+	if err != nil {
+		return nil, fmt.Errorf("rclone tuning: bwlimit: %w", err)
 	}
 
 	p := &Provider{
 		log:            log,
 		opt:            opt,
-		fsys:           fsys,
+		ci:             ci,
+		bw:             bw,
 		currentVersion: 1,
 	}
 
+	// the http client of the backend takes its timeouts from the config of this context
+	p.fsys, err = createRemoteFs(p.withConfig(context.Background()), opt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create remote filesystem: %w", err)
+	}
+
 	return p, nil
 }
 
-func (p *Provider) CleanupEnvs() {
-	p.opt.CleanupEnv()
+func (p *Provider) Backup(ctx context.Context) error {
+	job := hooks.Job{Name: p.opt.Name, Type: "remote", Provider: p.opt.Name, Database: p.opt.Database}
+
+	if err := p.opt.Hooks.Run(ctx, hooks.PreBackup, job); err != nil {
+		return fmt.Errorf("pre backup: %w", err)
+	}
+
+	remoteFile, size, err := p.backup(ctx)
+	if err != nil {
+		job.Err = err
+		if hookErr := p.opt.Hooks.Run(ctx, hooks.OnFailure, job); hookErr != nil {
+			p.log.Errorf("⚠️  Failure hook: %v", hookErr)
+		}
+		return err
+	}
+
+	job.File = remoteFile
+	job.Size = size
+	if err := p.opt.Hooks.Run(ctx, hooks.PostBackup, job); err != nil {
+		return fmt.Errorf("post backup: %w", err)
+	}
+
+	return nil
 }
 
-func (p *Provider) Backup(ctx context.Context) error {
-	defer p.CleanupEnvs()
+// backup generates the dump in a temp dir and uploads it, returns the remote path and size
+func (p *Provider) backup(ctx context.Context) (string, int64, error) {
 
 	log := p.log.WithMap(map[string]any{
 		"operation": "remote_backup",
@@ -91,6 +128,9 @@ func (p *Provider) Backup(ctx context.Context) error {
 
 	fileName := p.opt.GetRemoteFileName(p.currentVersion)
 	tmpDir := os.TempDir()
+	if p.opt.WorkDir != "" {
+		tmpDir = p.opt.WorkDir
+	}
 
 	log.Infof("   Generating backup: %s", fileName)
 
@@ -99,44 +139,65 @@ func (p *Provider) Backup(ctx context.Context) error {
 			return fileName
 		}),
 		backup.WithOutputDir(tmpDir),
+		backup.WithWorkDir(tmpDir),
 		backup.WithoutRetention(),
 		backup.WithDatabase(p.opt.Database),
 		backup.WithEncryptionKey(p.opt.EncryptionKey),
+		backup.WithMethod(p.opt.Method),
 	)
 
 	backupFile, err := localBackup.Run(ctx)
 	if err != nil {
-		return fmt.Errorf("backup generation failed: %w", err)
+		return "", 0, fmt.Errorf("backup generation failed: %w", err)
 	}
+	manifestFile := manifest.Name(backupFile)
 	defer func() {
 		_ = os.Remove(backupFile)
+		_ = os.Remove(manifestFile)
 	}()
+
+	var size int64
+	if info, err := os.Stat(backupFile); err == nil {
+		size = info.Size()
+	}
+
 	log.Infof("   Uploading to %s...", p.opt.Name)
 	if err := p.uploadFile(ctx, backupFile, fileName); err != nil {
-		return fmt.Errorf("upload failed: %w", err)
+		return "", 0, fmt.Errorf("upload failed: %w", err)
+	}
+
+	// the manifest keeps the backup ID, a backup without it is listed with a derived ID
+	if _, err := os.Stat(manifestFile); err == nil {
+		if err := p.uploadFile(ctx, manifestFile, manifest.Name(fileName)); err != nil {
+			log.Warnf("⚠️  Manifest upload failed: %v", err)
+		}
 	}
 
 	duration := time.Since(startTime)
 	log.Infof("✅ Remote backup to %s completed in %s", p.opt.Name, duration.Round(time.Second))
 
-	return nil
+	return p.opt.RemotePathFor(fileName), size, nil
 }
 
 func (p *Provider) List(ctx context.Context) ([]BackupFile, error) {
-	defer p.CleanupEnvs()
-
 	p.log.Infof("📂 Listing remote: %s", p.opt.Name)
 
+	ctx = p.withConfig(ctx)
 	entries, err := p.fsys.List(ctx, p.opt.Path)
 	if err != nil {
 		return nil, fmt.Errorf("list remote: %w", err)
 	}
 	fileMap := make(map[string]fs.DirEntry)
+	manifests := make(map[string]bool)
 
 	var files []BackupFile
 	for _, entry := range entries {
 		remote := entry.Remote()
 
+		if manifest.IsManifest(remote) {
+			manifests[strings.TrimSuffix(remote, manifest.Ext)] = true
+			continue
+		}
 		if !utils.IsFileBackup(remote) {
 			continue
 		}
@@ -152,9 +213,10 @@ func (p *Provider) List(ctx context.Context) ([]BackupFile, error) {
 	}
 	for _, entry := range fileMap {
 		files = append(files, BackupFile{
-			Name:    entry.Remote(),
-			Size:    entry.Size(),
-			ModTime: entry.ModTime(ctx),
+			Name:     entry.Remote(),
+			Size:     entry.Size(),
+			ModTime:  entry.ModTime(ctx),
+			Manifest: manifests[entry.Remote()],
 		})
 	}
 
@@ -162,10 +224,9 @@ func (p *Provider) List(ctx context.Context) ([]BackupFile, error) {
 	return files, nil
 }
 func (p *Provider) Download(ctx context.Context, fileName, localPath string) error {
-	defer p.CleanupEnvs()
-
 	p.log.Infof("📂 Download remote: %s", p.opt.Name)
 
+	ctx = p.withConfig(ctx)
 	obj, err := p.fsys.NewObject(ctx, fileName)
 
 	if err != nil {
@@ -177,6 +238,7 @@ func (p *Provider) Download(ctx context.Context, fileName, localPath string) err
 	if err != nil {
 		return fmt.Errorf("failed to open remote file: %w", err)
 	}
+	reader = p.bw.reader(ctx, reader, false)
 	defer func() {
 		_ = reader.Close()
 	}()
@@ -203,6 +265,117 @@ func (p *Provider) Download(ctx context.Context, fileName, localPath string) err
 	return nil
 }
 
+// Upload copies a local file to the remote, remoteName is relative to the provider path
+func (p *Provider) Upload(ctx context.Context, localPath, remoteName string) error {
+	return p.uploadFile(ctx, localPath, remoteName)
+}
+
+// CopyTo copies a file to dst under the same name and modification time, server-side when
+// both providers use the same backend and it allows it. rclone checks the size and the
+// hashes both sides have in common after the transfer
+func (p *Provider) CopyTo(ctx context.Context, dst *Provider, fileName string) error {
+	ctx = dst.withConfig(ctx)
+
+	src, err := p.fsys.NewObject(ctx, p.opt.RemotePathFor(fileName))
+	if err != nil {
+		return fmt.Errorf("find %s: %w", fileName, err)
+	}
+	remoteName := dst.opt.RemotePathFor(fileName)
+
+	copied := false
+	if p.sameBackend(dst) {
+		serverCtx, ci := fs.AddConfig(ctx)
+		ci.ServerSideAcrossConfigs = true
+
+		if _, err := operations.Copy(serverCtx, dst.fsys, nil, remoteName, src); err != nil {
+			p.log.Warnf("⚠️  Server-side copy of %s failed, streaming it: %v", fileName, err)
+		} else {
+			copied = true
+		}
+	}
+
+	if !copied {
+		if dst.bw != nil {
+			src = throttledObject{Object: src, bw: dst.bw}
+		}
+		if _, err := operations.Copy(ctx, dst.fsys, nil, remoteName, src); err != nil {
+			return fmt.Errorf("copy %s: %w", fileName, err)
+		}
+	}
+
+	if dst.opt.ObjectLock.Enabled() {
+		if err := dst.lock(ctx, remoteName); err != nil {
+			return fmt.Errorf("object lock: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Open streams a remote file, remoteName is relative to the provider path.
+// Returns fs.ErrorObjectNotFound when the file does not exist
+func (p *Provider) Open(ctx context.Context, remoteName string) (io.ReadCloser, error) {
+	ctx = p.withConfig(ctx)
+	obj, err := p.fsys.NewObject(ctx, p.opt.RemotePathFor(remoteName))
+	if err != nil {
+		return nil, err
+	}
+	in, err := obj.Open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.bw.reader(ctx, in, false), nil
+}
+
+// ListDir lists the files of a directory relative to the provider path, a missing
+// directory is empty
+func (p *Provider) ListDir(ctx context.Context, dir string) ([]BackupFile, error) {
+	ctx = p.withConfig(ctx)
+	entries, err := p.fsys.List(ctx, p.opt.RemotePathFor(dir))
+	if errors.Is(err, fs.ErrorDirNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list remote: %w", err)
+	}
+
+	var files []BackupFile
+	for _, entry := range entries {
+		obj, ok := entry.(fs.Object)
+		if !ok {
+			continue
+		}
+		files = append(files, BackupFile{
+			Name:    path.Base(obj.Remote()),
+			Path:    obj.Remote(),
+			ModTime: obj.ModTime(ctx),
+			Size:    obj.Size(),
+		})
+	}
+
+	return files, nil
+}
+
+// Delete removes a remote file, remoteName is relative to the provider path.
+// Returns ErrObjectLocked while the file is under retention or legal hold
+func (p *Provider) Delete(ctx context.Context, remoteName string) error {
+	ctx = p.withConfig(ctx)
+	obj, err := p.fsys.NewObject(ctx, p.opt.RemotePathFor(remoteName))
+	if err != nil {
+		return fmt.Errorf("find %s: %w", remoteName, err)
+	}
+	// removing a locked object only adds a delete marker hiding it, keep it visible until it expires
+	if p.opt.ObjectLock.Enabled() {
+		if err := p.checkLock(ctx, p.opt.RemotePathFor(remoteName)); err != nil {
+			return err
+		}
+	}
+	if err := obj.Remove(ctx); err != nil {
+		return fmt.Errorf("remove %s: %w", remoteName, err)
+	}
+	return nil
+}
+
 func (p *Provider) uploadFile(ctx context.Context, localPath, remoteName string) error {
 	file, err := os.Open(localPath)
 	if err != nil {
@@ -221,11 +394,18 @@ func (p *Provider) uploadFile(ctx context.Context, localPath, remoteName string)
 
 	fullPath := p.opt.RemotePathFor(remoteName)
 
-	_, err = operations.Rcat(ctx, p.fsys, fullPath, file, fileInfo.ModTime(), nil)
+	ctx = p.withConfig(ctx)
+	_, err = operations.Rcat(ctx, p.fsys, fullPath, p.bw.reader(ctx, file, true), fileInfo.ModTime(), nil)
 	if err != nil {
 		return fmt.Errorf("rclone upload failed: %w", err)
 	}
 
+	if p.opt.ObjectLock.Enabled() {
+		if err := p.lock(ctx, fullPath); err != nil {
+			return fmt.Errorf("object lock: %w", err)
+		}
+	}
+
 	p.log.Infof("   ✅ Uploaded: %s", remoteName)
 
 	return nil
@@ -245,7 +425,7 @@ func configureRclone() {
 	// - LogLevelDebug: Modo desenvolvimento (muito verboso)
 	// - LogLevelInfo: Modo produção (normal)
 	// - LogLevelError: Apenas erros
-	ci.LogLevel = fs.LogLevelDebug // Trocar para Debug se precisar
+	ci.LogLevel = fs.LogLevelNotice // rclone.log_level, ver ConfigureRclone
 
 	// Performance
 	ci.Transfers = 4                             // Conexões paralelas (bom para uploads grandes)
@@ -275,13 +455,11 @@ func configureRclone() {
 	ci.UserAgent = "pgopher-backup/1.0"
 }
 
-func createRemoteFs(opt *Options) (fs.Fs, error) {
-	ctx := context.Background()
-	err := opt.SetupEnv()
+func createRemoteFs(ctx context.Context, opt *Options) (fs.Fs, error) {
+	remotePath, err := configureRemote(opt)
 	if err != nil {
-		return nil, fmt.Errorf("setup environment: %w", err)
+		return nil, fmt.Errorf("configure remote: %w", err)
 	}
-	remotePath := opt.Name + ":"
 	fsys, err := fs.NewFs(ctx, remotePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create fs: %w", err)
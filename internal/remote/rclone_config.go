@@ -0,0 +1,140 @@
+package remote
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/rclone/rclone/fs/config"
+	"github.com/unknwon/goconfig"
+)
+
+// rclone keeps its remotes in an in-memory config storage (no rclone.conf is installed),
+// every provider is registered there instead of RCLONE_CONFIG_* env vars, so providers
+// running at the same time do not step on each other
+var (
+	rcloneConfigMu sync.Mutex
+	sectionSource  = map[string]string{} // section -> provider name or rclone.conf path
+)
+
+// configureRemote registers the provider in the rclone config and returns the remote to
+// open: an inline provider (type + config) is a section named after it, a provider with
+// remote: points to a section of an rclone.conf, which may wrap others (crypt, union...)
+func configureRemote(opt *Options) (string, error) {
+	rcloneConfigMu.Lock()
+	defer rcloneConfigMu.Unlock()
+
+	data := config.LoadedData()
+
+	if opt.Remote != "" {
+		path := opt.RcloneConfig
+		if path == "" {
+			path = config.GetConfigPath()
+		}
+		if err := loadRcloneConfig(data, path); err != nil {
+			return "", err
+		}
+
+		name, root, _ := strings.Cut(opt.Remote, ":")
+		if !data.HasSection(name) {
+			return "", fmt.Errorf("remote %s not found in %s", name, path)
+		}
+		return name + ":" + root, nil
+	}
+
+	if source, ok := sectionSource[opt.Name]; ok && source != opt.Name {
+		return "", fmt.Errorf("provider %s conflicts with the remote of the same name in %s", opt.Name, source)
+	}
+
+	writeSection(data, opt.Name, sectionValues(opt))
+	sectionSource[opt.Name] = opt.Name
+
+	// the local backend resolves an empty root against the working directory
+	if strings.EqualFold(opt.Type, "local") {
+		return opt.Name + ":/", nil
+	}
+	return opt.Name + ":", nil
+}
+
+func sectionValues(opt *Options) map[string]string {
+	values := map[string]string{"type": opt.Type}
+	for key, value := range opt.Config {
+		values[strings.ToLower(key)] = value
+	}
+	return values
+}
+
+// writeSection brings a section to the given values key by key. fs.NewFs reads the section
+// after the lock is released, so a section in use by another provider instance is never
+// deleted, and an unchanged one (the common case, same config) is not touched at all
+func writeSection(data config.Storage, section string, values map[string]string) {
+	for _, key := range data.GetKeyList(section) {
+		if _, ok := values[key]; !ok {
+			data.DeleteKey(section, key)
+		}
+	}
+	for key, value := range values {
+		if current, found := data.GetValue(section, key); !found || current != value {
+			data.SetValue(section, key, value)
+		}
+	}
+}
+
+// sameBackend reports whether dst is a remote of the same rclone backend supporting
+// server-side copies, the sections may still hold different accounts
+func (p *Provider) sameBackend(dst *Provider) bool {
+	rcloneConfigMu.Lock()
+	defer rcloneConfigMu.Unlock()
+
+	data := config.LoadedData()
+	srcType, _ := data.GetValue(p.fsys.Name(), "type")
+	dstType, _ := data.GetValue(dst.fsys.Name(), "type")
+
+	return srcType != "" && srcType == dstType && dst.fsys.Features().Copy != nil
+}
+
+// loadRcloneConfig copies the sections of an rclone.conf (decrypted with RCLONE_CONFIG_PASS
+// when needed) into the in-memory config, once per file
+func loadRcloneConfig(data config.Storage, path string) error {
+	for _, source := range sectionSource {
+		if source == path {
+			return nil
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open rclone config: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	decrypted, err := config.Decrypt(f)
+	if err != nil {
+		return fmt.Errorf("decrypt rclone config %s: %w", path, err)
+	}
+
+	file, err := goconfig.LoadFromReader(decrypted)
+	if err != nil {
+		return fmt.Errorf("parse rclone config %s: %w", path, err)
+	}
+
+	for _, section := range file.GetSectionList() {
+		if section == goconfig.DEFAULT_SECTION {
+			continue
+		}
+		if source, ok := sectionSource[section]; ok {
+			return fmt.Errorf("remote %s of %s is already defined by %s", section, path, source)
+		}
+
+		for _, key := range file.GetKeyList(section) {
+			value, _ := file.GetValue(section, key)
+			data.SetValue(section, key, value)
+		}
+		sectionSource[section] = path
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package remote
+
+import (
+	"context"
+	"time"
+
+	"github.com/BrunoTulio/pgopher/internal/config"
+	"github.com/rclone/rclone/fs"
+)
+
+// ConfigureRclone applies the process wide rclone settings, called once the config is loaded
+// and before any provider runs
+func ConfigureRclone(settings config.RcloneSettings) error {
+	initRclone()
+
+	if settings.LogLevel == "" {
+		return nil
+	}
+	ci := fs.GetConfig(context.Background())
+	return ci.LogLevel.Set(settings.LogLevel)
+}
+
+// tunedConfig returns a copy of the global rclone config with the provider tuning applied
+func tunedConfig(tuning config.RcloneTuning) (*fs.ConfigInfo, error) {
+	ci := new(fs.ConfigInfo)
+	*ci = *fs.GetConfig(context.Background())
+
+	// bwlimit is not set here, see bandwidthFor
+	if tuning.Transfers > 0 {
+		ci.Transfers = tuning.Transfers
+		ci.MultiThreadStreams = tuning.Transfers
+	}
+	if tuning.BufferSize != "" {
+		if err := ci.BufferSize.Set(tuning.BufferSize); err != nil {
+			return nil, err
+		}
+	}
+	if tuning.StreamingUploadCutoff != "" {
+		if err := ci.StreamingUploadCutoff.Set(tuning.StreamingUploadCutoff); err != nil {
+			return nil, err
+		}
+	}
+	if tuning.ConnectTimeout > 0 {
+		ci.ConnectTimeout = fs.Duration(time.Duration(tuning.ConnectTimeout) * time.Second)
+	}
+	if tuning.Timeout > 0 {
+		ci.Timeout = fs.Duration(time.Duration(tuning.Timeout) * time.Second)
+	}
+	if tuning.LowLevelRetries > 0 {
+		ci.LowLevelRetries = tuning.LowLevelRetries
+	}
+
+	return ci, nil
+}
+
+// withConfig returns a context carrying the provider rclone config, every rclone call of
+// the provider goes through it so providers running at the same time keep their own tuning
+func (p *Provider) withConfig(ctx context.Context) context.Context {
+	ctx, ci := fs.AddConfig(ctx)
+	*ci = *p.ci
+	return ctx
+}
@@ -0,0 +1,23 @@
+package replicate
+
+import "github.com/BrunoTulio/pgopher/internal/config"
+
+type (
+	FnOptions func(*Options)
+
+	Options struct {
+		Providers     []config.RemoteProvider
+		BackupDir     string // local backups, source of "local"
+		Database      config.DatabaseConfig
+		EncryptionKey string
+	}
+)
+
+func WithConfig(cfg *config.Config) FnOptions {
+	return func(opt *Options) {
+		opt.Providers = cfg.RemoteProviders
+		opt.BackupDir = cfg.LocalBackup.Dir
+		opt.Database = cfg.Database
+		opt.EncryptionKey = cfg.EncryptionKey
+	}
+}
@@ -0,0 +1,212 @@
+package replicate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/pgopher/internal/catalog"
+	"github.com/BrunoTulio/pgopher/internal/config"
+	"github.com/BrunoTulio/pgopher/internal/manifest"
+	"github.com/BrunoTulio/pgopher/internal/remote"
+	"github.com/BrunoTulio/pgopher/internal/utils"
+)
+
+type (
+	// Replicator copies catalog entries from a destination ("local" or a provider) to a provider
+	Replicator struct {
+		log     logr.Logger
+		opt     *Options
+		catalog *catalog.Catalog
+	}
+
+	// Filter selects the backups to copy: the given IDs, the backups taken since a time or
+	// all of them. An empty filter copies the newest backup
+	Filter struct {
+		IDs   []string
+		Since time.Time
+		All   bool
+	}
+
+	Result struct {
+		Copied  int
+		Skipped int // already on the destination
+		Bytes   int64
+	}
+)
+
+func New(catalog *catalog.Catalog, log logr.Logger) *Replicator {
+	return NewWithOptions(catalog, log)
+}
+
+func NewWithOptions(catalog *catalog.Catalog, log logr.Logger, opts ...FnOptions) *Replicator {
+	opt := &Options{}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	return &Replicator{
+		log:     log,
+		opt:     opt,
+		catalog: catalog,
+	}
+}
+
+// Copy copies the selected backups of from missing on to, oldest first. A file of the same
+// name on the destination is the same backup when the manifest IDs match or, without
+// manifest, the size and modification time; a different dump of the same name (fixed remote
+// names) is replaced. Failed files do not stop the others, their errors are returned together
+func (r *Replicator) Copy(ctx context.Context, from, to string, filter Filter) (Result, error) {
+	var result Result
+
+	if from == to {
+		return result, fmt.Errorf("source and destination are the same: %s", from)
+	}
+
+	files, err := r.catalog.List(ctx, from)
+	if err != nil {
+		return result, fmt.Errorf("list %s: %w", from, err)
+	}
+	selected, err := filter.apply(files)
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", from, err)
+	}
+
+	existing, err := r.catalog.List(ctx, to)
+	if err != nil {
+		return result, fmt.Errorf("list %s: %w", to, err)
+	}
+	replicated := make(map[string]catalog.BackupFile, len(existing))
+	for _, f := range existing {
+		replicated[f.Name] = f
+	}
+
+	src, err := r.provider(from)
+	if err != nil {
+		return result, err
+	}
+	dst, err := r.provider(to)
+	if err != nil {
+		return result, err
+	}
+
+	r.log.Infof("🔁 Replicating %d backup(s) from %s to %s", len(selected), from, to)
+
+	var errs []error
+	for i := len(selected) - 1; i >= 0; i-- {
+		f := selected[i]
+
+		if copied, ok := replicated[f.Name]; ok && sameBackup(f, copied) {
+			result.Skipped++
+			continue
+		}
+
+		r.log.Infof("   📤 %s (%s)", f.Name, utils.FormatBytes(f.Size))
+		if err := src.CopyTo(ctx, dst, f.Name); err != nil {
+			r.log.Errorf("   ❌ %s: %v", f.Name, err)
+			errs = append(errs, err)
+			continue
+		}
+		// the manifest keeps the backup ID on the destination
+		if f.ID != "" {
+			if err := src.CopyTo(ctx, dst, manifest.Name(f.Name)); err != nil {
+				r.log.Warnf("   ⚠️  Manifest of %s: %v", f.Name, err)
+			}
+		}
+		result.Copied++
+		result.Bytes += f.Size
+	}
+
+	if result.Copied > 0 {
+		r.catalog.Invalidate(to)
+	}
+
+	r.log.Infof("✅ %s -> %s: %d copied (%s), %d already there, %d failed",
+		from, to, result.Copied, utils.FormatBytes(result.Bytes), result.Skipped, len(errs))
+
+	return result, errors.Join(errs...)
+}
+
+// sameBackup compares a source backup with the file of the same name on the destination,
+// copies keep the modification time, to the second on some backends
+func sameBackup(src, dst catalog.BackupFile) bool {
+	if src.ID != "" || dst.ID != "" {
+		return src.ID == dst.ID
+	}
+	return src.Size == dst.Size && src.Time.Unix() == dst.Time.Unix()
+}
+
+// provider opens a destination, "local" is the backup dir through the rclone local backend
+func (r *Replicator) provider(name string) (*remote.Provider, error) {
+	providerCfg, err := r.providerConfig(name)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := remote.NewProviderWithOptions(r.log,
+		remote.WithOptions(providerCfg, r.opt.Database, r.opt.EncryptionKey))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return provider, nil
+}
+
+func (r *Replicator) providerConfig(name string) (config.RemoteProvider, error) {
+	if name == "local" {
+		dir, err := filepath.Abs(r.opt.BackupDir)
+		if err != nil {
+			return config.RemoteProvider{}, fmt.Errorf("local: %w", err)
+		}
+		return config.RemoteProvider{
+			Name:    "local",
+			Type:    "local",
+			Enabled: true,
+			Path:    strings.TrimPrefix(filepath.ToSlash(dir), "/"),
+		}, nil
+	}
+
+	for _, p := range r.opt.Providers {
+		if p.Name == name && p.Enabled {
+			return p, nil
+		}
+	}
+	return config.RemoteProvider{}, fmt.Errorf("provider %s not found or not enabled", name)
+}
+
+// apply returns the selected backups, newest first like the catalog
+func (f Filter) apply(files []catalog.BackupFile) ([]catalog.BackupFile, error) {
+	switch {
+	case len(f.IDs) > 0:
+		selected := make([]catalog.BackupFile, 0, len(f.IDs))
+		for _, file := range files {
+			for _, id := range f.IDs {
+				if file.Matches(id) {
+					selected = append(selected, file)
+				}
+			}
+		}
+		if len(selected) != len(f.IDs) {
+			return nil, fmt.Errorf("%d of the %d backup ID(s) not found", len(f.IDs)-len(selected), len(f.IDs))
+		}
+		return selected, nil
+	case f.All:
+		return files, nil
+	case !f.Since.IsZero():
+		var selected []catalog.BackupFile
+		for _, file := range files {
+			if !file.Time.Before(f.Since) {
+				selected = append(selected, file)
+			}
+		}
+		return selected, nil
+	default:
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no backups found")
+		}
+		return files[:1], nil
+	}
+}
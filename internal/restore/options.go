@@ -1,16 +1,31 @@
 package restore
 
 import (
+	"io"
+	"time"
+
 	"github.com/BrunoTulio/pgopher/internal/config"
+	"github.com/BrunoTulio/pgopher/internal/hooks"
 )
 
 type (
 	FnOptions func(*Options)
 	Options   struct {
-		Database      config.DatabaseConfig
-		Providers     []config.RemoteProvider
-		EncryptionKey string
-		Dir           string
+		Database       config.DatabaseConfig
+		Providers      []config.RemoteProvider
+		EncryptionKey  string
+		Dir            string
+		SnapshotKeep   int           // pre-restore snapshots kept per database
+		KeepOld        time.Duration // how long a blue/green restore keeps the replaced database
+		Selection      Selection
+		PostRestore    config.PostRestoreConfig
+		Hooks          *hooks.Runner // pre/post restore and failure hooks, nil = none
+		ListContents   bool          // print the archive TOC instead of restoring
+		Output         io.Writer     // where ListContents writes, defaults to stdout
+		DataDir        string        // physical backups: data directory to unpack into
+		RestoreCommand string        // physical backups: restore_command written to postgresql.auto.conf
+		RecoveryTime   time.Time     // physical backups: recover up to this time (PITR)
+		RecoveryLSN    string        // physical backups: recover up to this LSN (PITR)
 	}
 )
 
@@ -22,6 +37,9 @@ func WithConfig(
 		options.EncryptionKey = cfg.EncryptionKey
 		options.Providers = cfg.RemoteProviders
 		options.Dir = cfg.LocalBackup.Dir
+		options.SnapshotKeep = cfg.Restore.KeepSnapshots()
+		options.KeepOld = cfg.Restore.KeepOld()
+		options.PostRestore = cfg.Restore.PostRestore
 	}
 }
 
@@ -31,6 +49,64 @@ func WithEncryptionKey(key string) FnOptions {
 	}
 }
 
+// WithDatabase sets the database to restore into (defaults to the configured source)
+func WithDatabase(database config.DatabaseConfig) FnOptions {
+	return func(opts *Options) {
+		opts.Database = database
+	}
+}
+
+// WithSelection restores only part of the archive
+func WithSelection(selection Selection) FnOptions {
+	return func(opts *Options) {
+		opts.Selection = selection
+	}
+}
+
+func WithHooks(runner *hooks.Runner) FnOptions {
+	return func(opts *Options) {
+		opts.Hooks = runner
+	}
+}
+
+// WithoutPostRestore skips the configured post-restore steps
+func WithoutPostRestore() FnOptions {
+	return func(opts *Options) {
+		opts.PostRestore = config.PostRestoreConfig{}
+	}
+}
+
+// WithListContents prints the archive TOC to output instead of restoring
+func WithListContents(output io.Writer) FnOptions {
+	return func(opts *Options) {
+		opts.ListContents = true
+		opts.Output = output
+	}
+}
+
+// WithDataDir unpacks a physical backup into dir instead of running pg_restore
+func WithDataDir(dir string) FnOptions {
+	return func(opts *Options) {
+		opts.DataDir = dir
+	}
+}
+
+// WithRestoreCommand sets the restore_command used by the recovery of a physical backup
+func WithRestoreCommand(command string) FnOptions {
+	return func(opts *Options) {
+		opts.RestoreCommand = command
+	}
+}
+
+// WithRecoveryTarget stops the recovery of a physical backup at a time or LSN instead of
+// the end of the backup, the WAL is fetched with the restore command
+func WithRecoveryTarget(target time.Time, lsn string) FnOptions {
+	return func(opts *Options) {
+		opts.RecoveryTime = target
+		opts.RecoveryLSN = lsn
+	}
+}
+
 func (o *Options) IsEncryptEnabled() bool {
 	return o.EncryptionKey != ""
 }
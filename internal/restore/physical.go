@@ -0,0 +1,214 @@
+package restore
+
+import (
+	"archive/tar"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// extractPhysical unpacks a pg_basebackup bundle (see backup.executePgBasebackup) into the
+// data directory and prepares it to recover up to the end of the backup on the next start
+func (r *Restore) extractPhysical(input io.Reader) error {
+	dataDir := r.opt.DataDir
+	if err := prepareDir(dataDir); err != nil {
+		return err
+	}
+
+	r.log.Infof("📦 Unpacking physical backup into %s", dataDir)
+
+	var tablespaces map[string]string
+	bundle := tar.NewReader(input)
+
+	for {
+		header, err := bundle.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read backup: %w", err)
+		}
+
+		name := filepath.Base(header.Name)
+		switch {
+		case name == "base.tar":
+			if err := extractTar(tar.NewReader(bundle), dataDir); err != nil {
+				return fmt.Errorf("extract base.tar: %w", err)
+			}
+			if tablespaces, err = readTablespaceMap(dataDir); err != nil {
+				return err
+			}
+		case name == "pg_wal.tar":
+			if err := extractTar(tar.NewReader(bundle), filepath.Join(dataDir, "pg_wal")); err != nil {
+				return fmt.Errorf("extract pg_wal.tar: %w", err)
+			}
+		case name == "backup_manifest":
+			if err := writeFile(filepath.Join(dataDir, name), bundle, 0o600); err != nil {
+				return fmt.Errorf("write backup_manifest: %w", err)
+			}
+		case strings.HasSuffix(name, ".tar"):
+			oid := strings.TrimSuffix(name, ".tar")
+			location, ok := tablespaces[oid]
+			if !ok {
+				return fmt.Errorf("tablespace %s not found in tablespace_map", oid)
+			}
+			if err := prepareDir(location); err != nil {
+				return err
+			}
+			r.log.Infof("📦 Tablespace %s -> %s", oid, location)
+			if err := extractTar(tar.NewReader(bundle), location); err != nil {
+				return fmt.Errorf("extract %s: %w", name, err)
+			}
+		default:
+			r.log.Warnf("⚠️  Skipping unexpected entry %s", header.Name)
+		}
+	}
+
+	if err := r.writeRecoveryConfig(dataDir); err != nil {
+		return err
+	}
+
+	r.log.Infof("✅ Data directory ready, start PostgreSQL on %s to finish the recovery", dataDir)
+	return nil
+}
+
+// writeRecoveryConfig creates recovery.signal and appends the recovery settings to
+// postgresql.auto.conf: replay the WAL up to the target (by default the end of the
+// backup), then promote
+func (r *Restore) writeRecoveryConfig(dataDir string) error {
+	if err := os.WriteFile(filepath.Join(dataDir, "recovery.signal"), nil, 0o600); err != nil {
+		return fmt.Errorf("write recovery.signal: %w", err)
+	}
+
+	// restore_command is required with recovery.signal, "false" means the WAL in pg_wal is enough
+	restoreCommand := r.opt.RestoreCommand
+	if restoreCommand == "" {
+		restoreCommand = "false"
+	}
+
+	target := "recovery_target = 'immediate'"
+	switch {
+	case !r.opt.RecoveryTime.IsZero():
+		target = "recovery_target_time = " + confValue(r.opt.RecoveryTime.Format("2006-01-02 15:04:05.999999-07:00"))
+	case r.opt.RecoveryLSN != "":
+		target = "recovery_target_lsn = " + confValue(r.opt.RecoveryLSN)
+	}
+	r.log.Infof("🎯 Recovery: %s", target)
+
+	settings := fmt.Sprintf("\n# Added by pgopher restore\nrestore_command = %s\n%s\nrecovery_target_action = 'promote'\n",
+		confValue(restoreCommand), target)
+
+	f, err := os.OpenFile(filepath.Join(dataDir, "postgresql.auto.conf"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open postgresql.auto.conf: %w", err)
+	}
+	if _, err := f.WriteString(settings); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("write postgresql.auto.conf: %w", err)
+	}
+	return f.Close()
+}
+
+// prepareDir creates dir with the permissions PostgreSQL expects, an existing dir must be empty
+func prepareDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	switch {
+	case os.IsNotExist(err):
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return fmt.Errorf("create %s: %w", dir, err)
+		}
+	case err != nil:
+		return fmt.Errorf("read %s: %w", dir, err)
+	case len(entries) > 0:
+		return fmt.Errorf("%s is not empty", dir)
+	}
+
+	return os.Chmod(dir, 0o700)
+}
+
+// readTablespaceMap parses the "<oid> <path>" lines pg_basebackup writes in tar mode
+func readTablespaceMap(dataDir string) (map[string]string, error) {
+	f, err := os.Open(filepath.Join(dataDir, "tablespace_map"))
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open tablespace_map: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	tablespaces := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		oid, location, ok := strings.Cut(scanner.Text(), " ")
+		if ok {
+			tablespaces[oid] = location
+		}
+	}
+
+	return tablespaces, scanner.Err()
+}
+
+func extractTar(tr *tar.Reader, dest string) error {
+	if err := os.MkdirAll(dest, 0o700); err != nil {
+		return err
+	}
+	root := filepath.Clean(dest)
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(root, header.Name)
+		if target != root && !strings.HasPrefix(target, root+string(os.PathSeparator)) {
+			return fmt.Errorf("entry %s escapes %s", header.Name, dest)
+		}
+
+		mode := os.FileMode(header.Mode).Perm()
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, mode); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+				return err
+			}
+			if err := writeFile(target, tr, mode); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func writeFile(path string, r io.Reader, mode os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// confValue quotes a postgresql.conf string value
+func confValue(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
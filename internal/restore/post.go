@@ -0,0 +1,219 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BrunoTulio/pgopher/internal/database"
+	"github.com/BrunoTulio/pgopher/internal/utils"
+	"github.com/jackc/pgx/v5"
+)
+
+type (
+	// Result describes the last restore and its post-restore steps
+	Result struct {
+		Database string
+		Duration time.Duration
+		Steps    []StepResult
+	}
+
+	StepResult struct {
+		Name     string
+		Duration time.Duration
+		Err      error
+	}
+
+	postStep struct {
+		name string
+		run  func(ctx context.Context, client *database.Client) error
+	}
+)
+
+// Failed returns the post-restore steps that failed
+func (r Result) Failed() []StepResult {
+	var failed []StepResult
+	for _, step := range r.Steps {
+		if step.Err != nil {
+			failed = append(failed, step)
+		}
+	}
+	return failed
+}
+
+// Result returns the outcome of the last restore
+func (r *Restore) Result() Result {
+	return r.result
+}
+
+// runPostRestore runs the configured maintenance steps, a failed step is logged and
+// reported in the result but does not stop the others: the data is already restored
+func (r *Restore) runPostRestore(ctx context.Context) {
+	steps := r.postSteps()
+	if len(steps) == 0 {
+		return
+	}
+
+	r.log.Infof("🔧 Running %d post-restore step(s) on %s...", len(steps), r.opt.Database.Name)
+	client := database.NewClient(&r.opt.Database)
+
+	for _, step := range steps {
+		start := time.Now()
+		err := step.run(ctx, client)
+		result := StepResult{Name: step.name, Duration: time.Since(start), Err: err}
+		r.result.Steps = append(r.result.Steps, result)
+
+		if err != nil {
+			r.log.Errorf("❌ %s failed after %s: %v", step.name, utils.FormatDuration(result.Duration), err)
+			continue
+		}
+		r.log.Infof("✅ %s (%s)", step.name, utils.FormatDuration(result.Duration))
+	}
+}
+
+func (r *Restore) postSteps() []postStep {
+	post := r.opt.PostRestore
+	var steps []postStep
+
+	if post.ResetSequences {
+		steps = append(steps, execStep("reset sequences", resetSequencesSQL))
+	}
+	if post.RefreshMatViews {
+		steps = append(steps, execStep("refresh materialized views", refreshMatViewsSQL))
+	}
+	if post.Owner != "" {
+		steps = append(steps, execStep("owner "+post.Owner, fmt.Sprintf(ownerSQL, quoteLiteral(pgx.Identifier{post.Owner}.Sanitize()))))
+	}
+	for _, grant := range post.Grants {
+		steps = append(steps, execStep("grants "+grant.Role, grantSQL(grant.Role, grant.Privileges, grant.Sequences, grant.Schemas)))
+	}
+	for _, file := range post.SQLFiles {
+		steps = append(steps, sqlFileStep(file))
+	}
+
+	// statistics last, after sequences, views and user SQL changed the data
+	switch post.Analyze {
+	case "analyze":
+		steps = append(steps, execStep("analyze", "ANALYZE"))
+	case "vacuum_analyze":
+		steps = append(steps, execStep("vacuum analyze", "VACUUM ANALYZE"))
+	}
+
+	return steps
+}
+
+func execStep(name, query string) postStep {
+	return postStep{
+		name: name,
+		run: func(ctx context.Context, client *database.Client) error {
+			return client.Exec(ctx, query)
+		},
+	}
+}
+
+func sqlFileStep(file string) postStep {
+	return postStep{
+		name: "sql " + filepath.Base(file),
+		run: func(ctx context.Context, client *database.Client) error {
+			query, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			return client.Exec(ctx, string(query))
+		},
+	}
+}
+
+func grantSQL(role, privileges, sequences string, schemas []string) string {
+	if len(schemas) == 0 {
+		schemas = []string{"public"}
+	}
+
+	ident := pgx.Identifier{role}.Sanitize()
+	var b strings.Builder
+	for _, schema := range schemas {
+		s := pgx.Identifier{schema}.Sanitize()
+		fmt.Fprintf(&b, "GRANT USAGE ON SCHEMA %s TO %s;\n", s, ident)
+		fmt.Fprintf(&b, "GRANT %s ON ALL TABLES IN SCHEMA %s TO %s;\n", privileges, s, ident)
+		if sequences != "" {
+			fmt.Fprintf(&b, "GRANT %s ON ALL SEQUENCES IN SCHEMA %s TO %s;\n", sequences, s, ident)
+		}
+	}
+	return b.String()
+}
+
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// resetSequencesSQL moves every sequence owned by a column (serial and identity) past the
+// highest value of the column, so inserts do not collide with restored rows
+const resetSequencesSQL = `
+DO $$
+DECLARE r record;
+BEGIN
+    FOR r IN
+        SELECT format('%I.%I', sn.nspname, s.relname) AS seq,
+               format('%I.%I', tn.nspname, t.relname) AS tbl,
+               quote_ident(a.attname) AS col
+        FROM pg_class s
+        JOIN pg_namespace sn ON sn.oid = s.relnamespace
+        JOIN pg_depend d ON d.objid = s.oid
+            AND d.classid = 'pg_class'::regclass
+            AND d.refclassid = 'pg_class'::regclass
+            AND d.deptype IN ('a', 'i')
+        JOIN pg_class t ON t.oid = d.refobjid
+        JOIN pg_namespace tn ON tn.oid = t.relnamespace
+        JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = d.refobjsubid
+        WHERE s.relkind = 'S'
+    LOOP
+        EXECUTE format('SELECT setval(%L, COALESCE((SELECT MAX(%s) FROM %s), 0) + 1, false)', r.seq, r.col, r.tbl);
+    END LOOP;
+END $$`
+
+const refreshMatViewsSQL = `
+DO $$
+DECLARE r record;
+BEGIN
+    FOR r IN SELECT format('%I.%I', schemaname, matviewname) AS mv FROM pg_matviews LOOP
+        EXECUTE 'REFRESH MATERIALIZED VIEW ' || r.mv;
+    END LOOP;
+END $$`
+
+// ownerSQL changes the owner of the user schemas and relations, sequences owned by
+// a column follow their table
+const ownerSQL = `
+DO $$
+DECLARE r record;
+BEGIN
+    FOR r IN
+        SELECT nspname FROM pg_namespace
+        WHERE nspname NOT LIKE 'pg\_%%' AND nspname <> 'information_schema'
+    LOOP
+        EXECUTE format('ALTER SCHEMA %%I OWNER TO ', r.nspname) || %[1]s;
+    END LOOP;
+
+    FOR r IN
+        SELECT c.relkind, format('%%I.%%I', n.nspname, c.relname) AS obj
+        FROM pg_class c
+        JOIN pg_namespace n ON n.oid = c.relnamespace
+        WHERE c.relkind IN ('r', 'p', 'v', 'm', 'S', 'f')
+        AND n.nspname NOT LIKE 'pg\_%%' AND n.nspname <> 'information_schema'
+        AND NOT (c.relkind = 'S' AND EXISTS (
+            SELECT 1 FROM pg_depend d
+            WHERE d.objid = c.oid AND d.classid = 'pg_class'::regclass AND d.deptype IN ('a', 'i')
+        ))
+    LOOP
+        EXECUTE format('ALTER %%s %%s OWNER TO ',
+            CASE r.relkind
+                WHEN 'v' THEN 'VIEW'
+                WHEN 'm' THEN 'MATERIALIZED VIEW'
+                WHEN 'S' THEN 'SEQUENCE'
+                WHEN 'f' THEN 'FOREIGN TABLE'
+                ELSE 'TABLE'
+            END, r.obj) || %[1]s;
+    END LOOP;
+END $$`
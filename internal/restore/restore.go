@@ -2,6 +2,7 @@ package restore
 
 import (
 	"bufio"
+	"bytes"
 	"compress/gzip"
 	"context"
 	"fmt"
@@ -10,11 +11,13 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/BrunoTulio/logr"
 	"github.com/BrunoTulio/pgopher/internal/catalog"
 	"github.com/BrunoTulio/pgopher/internal/config"
 	"github.com/BrunoTulio/pgopher/internal/encoder"
+	"github.com/BrunoTulio/pgopher/internal/hooks"
 	"github.com/BrunoTulio/pgopher/internal/notify"
 	"github.com/BrunoTulio/pgopher/internal/remote"
 )
@@ -24,6 +27,7 @@ type Restore struct {
 	opt      *Options
 	catSvr   *catalog.Catalog
 	notifier notify.Notifier
+	result   Result
 }
 
 func New(catSvr *catalog.Catalog, log logr.Logger) *Restore {
@@ -31,7 +35,7 @@ func New(catSvr *catalog.Catalog, log logr.Logger) *Restore {
 }
 
 func NewWithOpts(catSvr *catalog.Catalog, log logr.Logger, opts ...FnOptions) *Restore {
-	opt := &Options{}
+	opt := &Options{Output: os.Stdout}
 
 	for _, o := range opts {
 		o(opt)
@@ -52,7 +56,7 @@ func (r *Restore) Run(ctx context.Context, providerName, shortID string) error {
 
 	var ff catalog.BackupFile
 	for _, file := range files {
-		if file.ShortID == shortID {
+		if file.Matches(shortID) {
 			ff = file
 			break
 		}
@@ -66,12 +70,17 @@ func (r *Restore) Run(ctx context.Context, providerName, shortID string) error {
 	var cleanup = func() {}
 
 	if providerName != "local" {
-		backupPath, cleanup, err = r.remotePath(ctx, providerName, ff)
+		backupPath, cleanup, err = r.remotePath(ctx, providerName, ff.Path)
 		if err != nil {
 			return err
 		}
 	}
 	defer cleanup()
+
+	return r.restoreFile(ctx, backupPath)
+}
+
+func (r *Restore) restoreFile(ctx context.Context, backupPath string) error {
 	backupFile, err := os.Open(backupPath)
 
 	if err != nil {
@@ -81,7 +90,11 @@ func (r *Restore) Run(ctx context.Context, providerName, shortID string) error {
 		_ = backupFile.Close()
 	}()
 
-	gzReader, err := r.toReader(backupFile, backupPath)
+	return r.restoreReader(ctx, backupFile, backupPath)
+}
+
+func (r *Restore) restoreReader(ctx context.Context, input io.Reader, name string) error {
+	gzReader, err := r.toReader(input, name)
 	if err != nil {
 		return err
 	}
@@ -89,19 +102,92 @@ func (r *Restore) Run(ctx context.Context, providerName, shortID string) error {
 		_ = gzReader.Close()
 	}()
 
-	err = r.executePgRestore(ctx, gzReader)
+	if r.opt.ListContents {
+		return r.printContents(ctx, gzReader)
+	}
+
+	if r.opt.DataDir == "" && catalog.IsPhysical(name) {
+		return fmt.Errorf("%s is a physical backup, restore it into a data directory (--data-dir)", filepath.Base(name))
+	}
+
+	if r.opt.Selection.IsPartial() {
+		r.log.Infof("🎯 Selective restore: %s", r.opt.Selection)
+	}
+
+	r.result = Result{Database: r.opt.Database.Name}
+	start := time.Now()
+	job := hooks.Job{Name: "restore", Type: "restore", File: name, Database: r.opt.Database}
+
+	if err := r.opt.Hooks.Run(ctx, hooks.PreRestore, job); err != nil {
+		return fmt.Errorf("pre restore: %w", err)
+	}
+
+	switch {
+	case r.opt.DataDir != "":
+		err = r.extractPhysical(gzReader)
+	case r.opt.Selection.needsTOC():
+		err = r.restoreWithTOC(ctx, gzReader)
+	default:
+		err = r.executePgRestore(ctx, gzReader, "", "")
+	}
 
 	if err != nil {
+		job.Err = err
+		if hookErr := r.opt.Hooks.Run(ctx, hooks.OnFailure, job); hookErr != nil {
+			r.log.Errorf("⚠️  Failure hook: %v", hookErr)
+		}
 		return fmt.Errorf("failed to restore backup: %w", err)
 	}
 
+	if r.opt.DataDir == "" {
+		r.runPostRestore(ctx)
+	}
+	r.result.Duration = time.Since(start)
+
+	if err := r.opt.Hooks.Run(ctx, hooks.PostRestore, job); err != nil {
+		return fmt.Errorf("post restore: %w", err)
+	}
+
 	return nil
 }
 
-func (r *Restore) toReader(backupFile *os.File, backupPath string) (io.ReadCloser, error) {
-	var reader io.Reader = backupFile
+func (r *Restore) restoreWithTOC(ctx context.Context, input io.Reader) error {
+	archivePath, cleanArchive, err := r.spoolArchive(input)
+	if err != nil {
+		return err
+	}
+	defer cleanArchive()
 
-	if strings.HasSuffix(backupPath, ".age") {
+	tocPath, cleanTOC, err := r.writeTOC(ctx, archivePath)
+	if err != nil {
+		return err
+	}
+	defer cleanTOC()
+
+	return r.executePgRestore(ctx, nil, archivePath, tocPath)
+}
+
+// printContents prints the archive TOC, it can be edited and passed back as a TOC file
+func (r *Restore) printContents(ctx context.Context, input io.Reader) error {
+	toc, err := r.listArchive(ctx, "", input)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.opt.Output.Write(toc)
+	return err
+}
+
+// toReader decrypts (age) and decompresses (gzip) the backup in streaming. The format is
+// detected from the content, falling back to the file extension, so stdin and files with
+// unusual names work too. Plain pg_dump archives are passed through.
+func (r *Restore) toReader(input io.Reader, name string) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(input)
+	var reader io.Reader = buffered
+
+	encrypted := strings.HasSuffix(name, ".age") || hasPrefix(buffered, ageMagic)
+
+	if encrypted {
 		if !r.opt.IsEncryptEnabled() {
 			return nil, fmt.Errorf("backup is encrypted but no encryption key configured")
 		}
@@ -113,33 +199,44 @@ func (r *Restore) toReader(backupFile *os.File, backupPath string) (io.ReadClose
 			return nil, fmt.Errorf("failed to create encryptor: %w", err)
 		}
 
-		decryptReader, err := enc.DecryptReader(backupFile) // ← Decripta o arquivo
+		decryptReader, err := enc.DecryptReader(buffered) // ← Decripta o arquivo
 		if err != nil {
 			return nil, fmt.Errorf("decryption failed: %w", err)
 		}
 
-		reader = decryptReader
+		buffered = bufio.NewReader(decryptReader)
+		reader = buffered
 		r.log.Info("✅ Decryption completed")
 	}
 
+	if !hasPrefix(buffered, gzipMagic) {
+		r.log.Info("📦 Backup is not gzip compressed, restoring as is")
+		return io.NopCloser(reader), nil
+	}
+
 	r.log.Info("📦 Decompressing (streaming)...")
 	gzReader, err := gzip.NewReader(reader) // ← Descomprime o resultado
 	if err != nil {
 		return nil, fmt.Errorf("failed to create gzip reader: %w", err)
 	}
 
-	if strings.HasSuffix(backupPath, ".age") {
-		return struct {
-			io.Reader
-			io.Closer
-		}{gzReader, gzReader}, nil
-	}
-
-	r.log.Info("✅ Decompression completed")
 	return gzReader, nil
 }
 
-func (r *Restore) remotePath(ctx context.Context, providerName string, ff catalog.BackupFile) (string, func(), error) {
+var (
+	ageMagic  = []byte("age-encryption.org/")
+	gzipMagic = []byte{0x1f, 0x8b}
+)
+
+func hasPrefix(reader *bufio.Reader, magic []byte) bool {
+	head, err := reader.Peek(len(magic))
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(head, magic)
+}
+
+func (r *Restore) remotePath(ctx context.Context, providerName, objectPath string) (string, func(), error) {
 	var remoteProvider config.RemoteProvider
 
 	for _, provider := range r.opt.Providers {
@@ -158,10 +255,10 @@ func (r *Restore) remotePath(ctx context.Context, providerName string, ff catalo
 		return "", nil, fmt.Errorf("new remote provider: %w", err)
 	}
 
-	tmpPath := filepath.Join(os.TempDir(), ff.Name)
+	tmpPath := filepath.Join(os.TempDir(), filepath.Base(objectPath))
 	r.log.Infof("📥 Downloading to %s...", tmpPath)
 
-	err = provider.Download(ctx, ff.Path, tmpPath)
+	err = provider.Download(ctx, objectPath, tmpPath)
 
 	if err != nil {
 		return "", nil, fmt.Errorf("download backup: %w", err)
@@ -177,7 +274,8 @@ func (r *Restore) remotePath(ctx context.Context, providerName string, ff catalo
 	return tmpPath, clean, nil
 }
 
-func (r *Restore) executePgRestore(ctx context.Context, input io.Reader) error {
+// executePgRestore restores from input, or from archivePath when set (required with a TOC list)
+func (r *Restore) executePgRestore(ctx context.Context, input io.Reader, archivePath, tocPath string) error {
 	r.log.Info("🔄 Restoring database...")
 
 	args := []string{
@@ -185,17 +283,25 @@ func (r *Restore) executePgRestore(ctx context.Context, input io.Reader) error {
 		"-p", fmt.Sprintf("%d", r.opt.Database.Port),
 		"-U", r.opt.Database.Username,
 		"-d", r.opt.Database.Name,
-		"--clean",     // DROP objects before creating
-		"--if-exists", // Does not fail if object does not exist
-		"--no-owner",  // Do not restore ownership
-		"--no-acl",    // Do not restore ACLs
+		"--no-owner", // Do not restore ownership
+		"--no-acl",   // Do not restore ACLs
 		"--verbose",
 		"--single-transaction", // All in one transaction (rollback if failed)
 	}
+	args = append(args, r.opt.Selection.args()...)
+
+	if tocPath != "" {
+		args = append(args, "--use-list", tocPath)
+	}
+	if archivePath != "" {
+		args = append(args, archivePath)
+	}
 
 	cmd := exec.CommandContext(ctx, "pg_restore", args...)
 	cmd.Env = append(os.Environ(), fmt.Sprintf("PGPASSWORD=%s", r.opt.Database.Password))
-	cmd.Stdin = input
+	if archivePath == "" {
+		cmd.Stdin = input
+	}
 
 	stderrPipe, err := cmd.StderrPipe()
 	if err != nil {
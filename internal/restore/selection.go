@@ -0,0 +1,284 @@
+package restore
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Selection restricts a restore to part of the archive, the zero value restores everything
+type Selection struct {
+	Tables        []string // pg_restore --table, only the named tables
+	Schemas       []string // pg_restore --schema
+	ExcludeTables []string // "table" or "schema.table", filtered out of the archive TOC
+	DataOnly      bool
+	SchemaOnly    bool
+	TOCFile       string // pg_restore --use-list, a TOC from --list-contents edited by hand
+}
+
+func (s Selection) IsPartial() bool {
+	return len(s.Tables) > 0 || len(s.Schemas) > 0 || len(s.ExcludeTables) > 0 ||
+		s.DataOnly || s.SchemaOnly || s.TOCFile != ""
+}
+
+// needsTOC reports whether pg_restore needs a seekable archive and a TOC list
+func (s Selection) needsTOC() bool {
+	return len(s.ExcludeTables) > 0 || s.TOCFile != ""
+}
+
+func (s Selection) args() []string {
+	var args []string
+
+	// --clean cannot be used with --data-only, rows are appended to the existing tables
+	if !s.DataOnly {
+		args = append(args,
+			"--clean",     // DROP objects before creating
+			"--if-exists", // Does not fail if object does not exist
+		)
+	}
+
+	for _, schema := range s.Schemas {
+		args = append(args, "--schema", schema)
+	}
+	for _, table := range s.Tables {
+		args = append(args, "--table", table)
+	}
+	if s.DataOnly {
+		args = append(args, "--data-only")
+	}
+	if s.SchemaOnly {
+		args = append(args, "--schema-only")
+	}
+
+	return args
+}
+
+func (s Selection) String() string {
+	var parts []string
+	if len(s.Schemas) > 0 {
+		parts = append(parts, "schemas="+strings.Join(s.Schemas, ","))
+	}
+	if len(s.Tables) > 0 {
+		parts = append(parts, "tables="+strings.Join(s.Tables, ","))
+	}
+	if len(s.ExcludeTables) > 0 {
+		parts = append(parts, "exclude="+strings.Join(s.ExcludeTables, ","))
+	}
+	if s.DataOnly {
+		parts = append(parts, "data-only")
+	}
+	if s.SchemaOnly {
+		parts = append(parts, "schema-only")
+	}
+	if s.TOCFile != "" {
+		parts = append(parts, "toc="+s.TOCFile)
+	}
+	if len(parts) == 0 {
+		return "full"
+	}
+	return strings.Join(parts, " ")
+}
+
+// spoolArchive writes the decompressed archive to a temp file, pg_restore needs
+// a seekable input to restore from a TOC list
+func (r *Restore) spoolArchive(input io.Reader) (string, func(), error) {
+	tmp, err := os.CreateTemp("", "pgopher-restore-*.dump")
+	if err != nil {
+		return "", nil, fmt.Errorf("create temp archive: %w", err)
+	}
+
+	clean := func() {
+		if err := os.Remove(tmp.Name()); err != nil {
+			r.log.Warnf("⚠️  Failed to remove temp file %s: %v", tmp.Name(), err)
+		}
+	}
+
+	r.log.Infof("📦 Spooling archive to %s...", tmp.Name())
+	if _, err := io.Copy(tmp, input); err != nil {
+		_ = tmp.Close()
+		clean()
+		return "", nil, fmt.Errorf("write temp archive: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		clean()
+		return "", nil, fmt.Errorf("close temp archive: %w", err)
+	}
+
+	return tmp.Name(), clean, nil
+}
+
+// listArchive returns the archive TOC as printed by pg_restore --list
+func (r *Restore) listArchive(ctx context.Context, archivePath string, input io.Reader) ([]byte, error) {
+	args := []string{"--list"}
+	if archivePath != "" {
+		args = append(args, archivePath)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "pg_restore", args...)
+	cmd.Stdin = input
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("pg_restore --list failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// writeTOC builds the TOC list used with --use-list: the user supplied file or the
+// archive TOC without the excluded tables
+func (r *Restore) writeTOC(ctx context.Context, archivePath string) (string, func(), error) {
+	if len(r.opt.Selection.ExcludeTables) == 0 {
+		return r.opt.Selection.TOCFile, func() {}, nil
+	}
+
+	var (
+		toc []byte
+		err error
+	)
+	if r.opt.Selection.TOCFile != "" {
+		toc, err = os.ReadFile(r.opt.Selection.TOCFile)
+	} else {
+		toc, err = r.listArchive(ctx, archivePath, nil)
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("read TOC: %w", err)
+	}
+
+	filtered, removed := excludeTables(toc, r.opt.Selection.ExcludeTables)
+	r.log.Infof("🚫 Excluding %d TOC entr(ies) for %s", removed, strings.Join(r.opt.Selection.ExcludeTables, ", "))
+
+	tmp, err := os.CreateTemp("", "pgopher-restore-*.toc")
+	if err != nil {
+		return "", nil, fmt.Errorf("create TOC file: %w", err)
+	}
+	clean := func() {
+		_ = os.Remove(tmp.Name())
+	}
+
+	if _, err := tmp.Write(filtered); err != nil {
+		_ = tmp.Close()
+		clean()
+		return "", nil, fmt.Errorf("write TOC file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		clean()
+		return "", nil, fmt.Errorf("close TOC file: %w", err)
+	}
+
+	return tmp.Name(), clean, nil
+}
+
+// tocDescs lists the multi word entry types of a pg_restore TOC, longest first
+var tocDescs = []string{
+	"MATERIALIZED VIEW DATA",
+	"SEQUENCE OWNED BY",
+	"MATERIALIZED VIEW",
+	"FK CONSTRAINT",
+	"SEQUENCE SET",
+	"ROW SECURITY",
+	"TABLE DATA",
+	"DEFAULT ACL",
+}
+
+// tableEntries are the entry types removed together with an excluded table,
+// their tag starts with the table name
+var tableEntries = map[string]bool{
+	"TABLE":         true,
+	"TABLE DATA":    true,
+	"CONSTRAINT":    true,
+	"FK CONSTRAINT": true,
+	"DEFAULT":       true,
+	"TRIGGER":       true,
+	"POLICY":        true,
+	"ROW SECURITY":  true,
+	"ACL":           true,
+	"COMMENT":       true,
+}
+
+// excludeTables comments out the TOC entries of the given tables,
+// a TOC line looks like "215; 1259 16390 TABLE public users postgres"
+func excludeTables(toc []byte, tables []string) ([]byte, int) {
+	var (
+		out     bytes.Buffer
+		removed int
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(toc))
+	scanner.Buffer(make([]byte, 64*1024), 2*1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		desc, schema, tag, ok := parseTOCLine(line)
+		if ok && tableEntries[desc] && matchesTable(schema, tag, desc, tables) {
+			out.WriteString(";")
+			removed++
+		}
+		out.WriteString(line)
+		out.WriteString("\n")
+	}
+
+	return out.Bytes(), removed
+}
+
+func parseTOCLine(line string) (desc, schema, tag string, ok bool) {
+	if strings.HasPrefix(line, ";") {
+		return "", "", "", false
+	}
+
+	_, rest, found := strings.Cut(line, "; ")
+	if !found {
+		return "", "", "", false
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) < 5 { // table oid, oid, desc, schema, owner
+		return "", "", "", false
+	}
+	fields = fields[2:]
+
+	desc = fields[0]
+	joined := strings.Join(fields, " ")
+	for _, d := range tocDescs {
+		if strings.HasPrefix(joined, d+" ") {
+			desc = d
+			break
+		}
+	}
+	fields = fields[len(strings.Fields(desc)):]
+	if len(fields) < 3 {
+		return "", "", "", false
+	}
+
+	// ACL and COMMENT tags are prefixed with the object type, ex: "TABLE users"
+	tag = strings.Join(fields[1:len(fields)-1], " ")
+	tag = strings.TrimPrefix(tag, "TABLE ")
+
+	return desc, fields[0], tag, true
+}
+
+func matchesTable(schema, tag, desc string, tables []string) bool {
+	name := tag
+	if desc != "TABLE" && desc != "TABLE DATA" {
+		name, _, _ = strings.Cut(tag, " ")
+	}
+
+	for _, t := range tables {
+		if s, n, qualified := strings.Cut(t, "."); qualified {
+			if s == schema && n == name {
+				return true
+			}
+			continue
+		}
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/BrunoTulio/pgopher/internal/backup"
+	"github.com/BrunoTulio/pgopher/internal/catalog"
+	"github.com/BrunoTulio/pgopher/internal/config"
+	"github.com/BrunoTulio/pgopher/internal/utils"
+)
+
+// Snapshot backs up the target database before it is replaced, the snapshot is kept
+// under the local backup dir and tagged as pre-restore in the catalog
+func (r *Restore) Snapshot(ctx context.Context) (string, error) {
+	r.log.Infof("📸 Taking pre-restore snapshot of %s...", r.opt.Database.Name)
+
+	keep := r.opt.SnapshotKeep
+	snapshot := backup.NewWithFnOptions(r.log,
+		backup.WithDatabase(r.opt.Database),
+		backup.WithOutputDir(catalog.SnapshotDir(r.opt.Dir)),
+		backup.WithEncryptionKey(r.opt.EncryptionKey),
+		backup.WithRetention(config.RetentionConfig{MaxBackups: &keep}),
+		backup.WithGenerateFileName(func() string {
+			timestamp := time.Now().Format("20060102-150405")
+			return fmt.Sprintf("%s-%s-%s.sql.gz", r.opt.Database.Name, catalog.TagPreRestore, timestamp)
+		}),
+	)
+
+	path, err := snapshot.Run(ctx)
+	if err != nil {
+		return "", fmt.Errorf("pre-restore snapshot: %w", err)
+	}
+
+	r.log.Infof("📸 Snapshot saved: %s", path)
+	return path, nil
+}
+
+// Undo restores the newest pre-restore snapshot of the target database
+func (r *Restore) Undo(ctx context.Context) error {
+	snapshots, err := r.catSvr.Snapshots(r.opt.Database.Name)
+	if err != nil {
+		return fmt.Errorf("list snapshots: %w", err)
+	}
+
+	if len(snapshots) == 0 {
+		return fmt.Errorf("no pre-restore snapshot found for %s", r.opt.Database.Name)
+	}
+
+	latest := snapshots[0]
+	r.log.Infof("⏪ Rolling back to snapshot %s (taken %s, %s ago)",
+		latest.Name, latest.ModTime, utils.FormatDuration(time.Since(latest.Time)))
+
+	// the snapshot is a full backup, a selection would only roll back part of it
+	r.opt.Selection = Selection{}
+
+	return r.restoreFile(ctx, latest.Path)
+}
@@ -0,0 +1,70 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+)
+
+// RunFile restores from a local path, "-" (stdin) or an http(s) URL, without the catalog
+func (r *Restore) RunFile(ctx context.Context, source string) error {
+	switch {
+	case source == "-":
+		r.log.Info("📥 Reading backup from stdin...")
+		return r.restoreReader(ctx, os.Stdin, "")
+
+	case strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://"):
+		return r.restoreURL(ctx, source)
+
+	default:
+		if _, err := os.Stat(source); err != nil {
+			return fmt.Errorf("backup file: %w", err)
+		}
+		r.log.Infof("📂 Restoring from file %s", source)
+		return r.restoreFile(ctx, source)
+	}
+}
+
+// RunRemoteObject restores an ad-hoc object "<provider>:<path>" from a configured provider
+func (r *Restore) RunRemoteObject(ctx context.Context, target string) error {
+	providerName, objectPath, ok := strings.Cut(target, ":")
+	if !ok || providerName == "" || objectPath == "" {
+		return fmt.Errorf("invalid remote object %q, expected <provider>:<path>", target)
+	}
+
+	r.log.Infof("☁️  Restoring from %s (%s)", providerName, objectPath)
+
+	backupPath, cleanup, err := r.remotePath(ctx, providerName, objectPath)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	return r.restoreFile(ctx, backupPath)
+}
+
+func (r *Restore) restoreURL(ctx context.Context, url string) error {
+	r.log.Infof("🌐 Streaming backup from %s", url)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("download backup: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("download backup: status %s", resp.Status)
+	}
+
+	return r.restoreReader(ctx, resp.Body, path.Base(req.URL.Path))
+}
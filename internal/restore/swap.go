@@ -0,0 +1,85 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/BrunoTulio/pgopher/internal/database"
+	"github.com/BrunoTulio/pgopher/internal/utils"
+)
+
+const swapAttempts = 5
+
+// Swap runs a blue/green restore: restoreInto restores into a new database "<db>_restore_<ts>"
+// while the target stays online, then, once the new database is validated, the target is
+// renamed to "<db>_old_<ts>" and the new database takes its name. The old database is
+// dropped by CleanupOld after KeepOld.
+func (r *Restore) Swap(ctx context.Context, restoreInto func(ctx context.Context) error) error {
+	target := r.opt.Database
+	client := database.NewClient(&target)
+
+	r.CleanupOld(ctx)
+
+	fresh, old, err := client.SwapNames(time.Now())
+	if err != nil {
+		return err
+	}
+
+	freshClient := client.WithName(fresh)
+	r.log.Infof("🟢 Blue/green restore into %s", fresh)
+	if _, err := freshClient.CreateDatabase(ctx); err != nil {
+		return fmt.Errorf("create %s: %w", fresh, err)
+	}
+
+	r.opt.Database.Name = fresh
+	err = restoreInto(ctx)
+	r.opt.Database = target
+
+	if err == nil {
+		err = r.validateSwap(ctx, freshClient)
+	}
+	if err != nil {
+		r.log.Warnf("🧹 Dropping %s, %s was not touched", fresh, target.Name)
+		if dropErr := freshClient.DropDatabase(context.WithoutCancel(ctx)); dropErr != nil {
+			r.log.Errorf("Failed to drop %s: %v", fresh, dropErr)
+		}
+		return err
+	}
+
+	r.log.Infof("🔀 Swapping %s → %s, %s → %s", target.Name, old, fresh, target.Name)
+	start := time.Now()
+	if err := client.Swap(ctx, fresh, old, swapAttempts); err != nil {
+		return fmt.Errorf("swap databases (restored data kept in %s): %w", fresh, err)
+	}
+
+	r.log.Infof("✅ Swap completed in %s", utils.FormatDuration(time.Since(start)))
+	r.log.Infof("🗄️  Previous database kept as %s for %s", old, utils.FormatDuration(r.opt.KeepOld))
+	return nil
+}
+
+func (r *Restore) validateSwap(ctx context.Context, client *database.Client) error {
+	r.log.Infof("🔎 Validating %s...", client.Name())
+
+	tables, err := client.CountTables(ctx)
+	if err != nil {
+		return fmt.Errorf("validate %s: %w", client.Name(), err)
+	}
+	if tables == 0 {
+		return fmt.Errorf("validate %s: restored database has no tables", client.Name())
+	}
+
+	r.log.Infof("✅ %s has %d table(s)", client.Name(), tables)
+	return nil
+}
+
+// CleanupOld drops the databases replaced or left behind by blue/green restores older than KeepOld
+func (r *Restore) CleanupOld(ctx context.Context) {
+	dropped, err := database.NewClient(&r.opt.Database).DropOldDatabases(ctx, r.opt.KeepOld)
+	for _, name := range dropped {
+		r.log.Infof("🧹 Dropped old database %s", name)
+	}
+	if err != nil {
+		r.log.Warnf("⚠️  Failed to drop old databases of %s: %v", r.opt.Database.Name, err)
+	}
+}
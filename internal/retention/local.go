@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/pgopher/internal/manifest"
 	"github.com/BrunoTulio/pgopher/internal/utils"
 )
 
@@ -75,6 +76,12 @@ func (l *Local) Run(ctx context.Context) error {
 		}
 	}
 
+	if l.opt.OnRemove != nil {
+		for _, backup := range backupRemoved {
+			l.opt.OnRemove(backup)
+		}
+	}
+
 	l.log.Infof("✅ Cleanup completed:")
 	l.log.Infof("   Removed: %d backup(s)", backupRemoved.Len())
 	l.log.Infof("   Kept: %d backup(s)", len(backups)-backupRemoved.Len())
@@ -84,7 +91,7 @@ func (l *Local) Run(ctx context.Context) error {
 }
 
 func (l *Local) findBackups() (BackupFiles, error) {
-	pattern := filepath.Join(l.opt.OutputDir, fmt.Sprintf("%s-*.sql.gz*", l.opt.DatabaseName))
+	pattern := filepath.Join(l.opt.OutputDir, fmt.Sprintf("%s-*.gz*", l.opt.DatabaseName))
 
 	matches, err := filepath.Glob(pattern)
 
@@ -95,6 +102,10 @@ func (l *Local) findBackups() (BackupFiles, error) {
 	backups := make(BackupFiles, 0, len(matches))
 
 	for _, path := range matches {
+		if !utils.IsFileBackup(path) {
+			continue // manifests
+		}
+
 		info, err := os.Stat(path)
 		if err != nil {
 			l.log.Warnf("Failed to stat %s: %v", path, err)
@@ -130,7 +141,7 @@ func (l *Local) cleanByCount(backups BackupFiles, maxBackups int) (BackupFiles,
 			utils.FormatDuration(time.Since(backup.ModTime)),
 			utils.FormatBytes(backup.Size))
 
-		err := os.Remove(backup.Path)
+		err := removeBackup(backup.Path)
 
 		if err != nil {
 			l.log.Warnf("Failed to remove backup %s: %v", backup.Path, err)
@@ -166,7 +177,7 @@ func (l *Local) cleanByDays(backups BackupFiles, retentionDays int) (BackupFiles
 			utils.FormatDuration(time.Since(backup.ModTime)),
 			utils.FormatBytes(backup.Size))
 
-		if err := os.Remove(backup.Path); err != nil {
+		if err := removeBackup(backup.Path); err != nil {
 			l.log.Warnf("Failed to remove backup %s: %v", backup.Path, err)
 			continue
 		}
@@ -176,6 +187,15 @@ func (l *Local) cleanByDays(backups BackupFiles, retentionDays int) (BackupFiles
 	return removed, nil
 }
 
+// removeBackup removes a backup and its manifest
+func removeBackup(path string) error {
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	_ = os.Remove(manifest.Name(path))
+	return nil
+}
+
 func (b BackupFiles) Paths() []string {
 	paths := make([]string, len(b), len(b))
 	for i, backup := range b {
@@ -9,6 +9,7 @@ type (
 		Retention    config.RetentionConfig
 		OutputDir    string
 		DatabaseName string
+		OnRemove     func(BackupFile) // called for every backup removed by cleanup
 	}
 )
 
@@ -39,6 +40,12 @@ func WithDatabaseName(name string) FnOptions {
 	}
 }
 
+func WithOnRemove(fn func(BackupFile)) FnOptions {
+	return func(opts *Options) {
+		opts.OnRemove = fn
+	}
+}
+
 func (o *Options) HasRetention() bool {
 	return o.Retention.HasMaxBackups() || o.Retention.HasRetentionDays()
 }
@@ -10,13 +10,18 @@ type JobInfo struct {
 	ID       cron.EntryID
 	Name     string // ex: "local", "dropbox", "gdrive"
 	Type     string // ex: "local", "remote"
-	Schedule string // "03:00"
+	Schedule string // "03:00", "*/15 * * * *", "@every 4h"
+	CronExpr string // normalized expression given to cron
 }
 
 type JobStatus struct {
 	Name     string
 	Type     string
 	Schedule string
+	CronExpr string
 	Next     time.Time
 	Prev     time.Time
+	NextRuns []time.Time
 }
+
+const nextRunsCount = 5
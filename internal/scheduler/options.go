@@ -1,9 +1,14 @@
 package scheduler
 
 import (
+	"context"
 	"time"
 
+	"github.com/BrunoTulio/pgopher/internal/catalog"
 	"github.com/BrunoTulio/pgopher/internal/config"
+	"github.com/BrunoTulio/pgopher/internal/digest"
+	"github.com/BrunoTulio/pgopher/internal/hooks"
+	"github.com/BrunoTulio/pgopher/internal/replicate"
 )
 
 type Options struct {
@@ -12,6 +17,16 @@ type Options struct {
 	Local         config.LocalBackupConfig
 	Database      config.DatabaseConfig
 	EncryptionKey string
+	WorkDir       string
+	Scheduler     config.SchedulerConfig
+	Catalog       *catalog.Catalog // used by catch-up to find the last backup, invalidated by remote backups
+	Recorder      *digest.Recorder
+	Digest        *digest.Digest
+	DigestCron    string
+	Cleanup       []func(ctx context.Context) // hourly housekeeping steps, ex: old blue/green databases
+	Hooks         *hooks.Runner               // passed to the remote providers
+	Replication   []config.ReplicationJob
+	Replicator    *replicate.Replicator // runs the replication jobs, nil = not scheduled
 }
 
 func WithConfig(cfg *config.Config) func(*Options) {
@@ -21,5 +36,46 @@ func WithConfig(cfg *config.Config) func(*Options) {
 		o.Local = cfg.LocalBackup
 		o.Database = cfg.Database
 		o.EncryptionKey = cfg.EncryptionKey
+		o.WorkDir = cfg.WorkDir
+		o.Scheduler = cfg.Scheduler
+		o.Replication = cfg.Replication
+	}
+}
+
+func WithRecorder(recorder *digest.Recorder) func(*Options) {
+	return func(o *Options) {
+		o.Recorder = recorder
+	}
+}
+
+func WithDigest(d *digest.Digest, cronExpr string) func(*Options) {
+	return func(o *Options) {
+		o.Digest = d
+		o.DigestCron = cronExpr
+	}
+}
+
+func WithCatalog(c *catalog.Catalog) func(*Options) {
+	return func(o *Options) {
+		o.Catalog = c
+	}
+}
+
+func WithHooks(runner *hooks.Runner) func(*Options) {
+	return func(o *Options) {
+		o.Hooks = runner
+	}
+}
+
+// WithCleanup adds hourly housekeeping steps, run in order with a timeout each
+func WithCleanup(steps ...func(ctx context.Context)) func(*Options) {
+	return func(o *Options) {
+		o.Cleanup = append(o.Cleanup, steps...)
+	}
+}
+
+func WithReplicator(r *replicate.Replicator) func(*Options) {
+	return func(o *Options) {
+		o.Replicator = r
 	}
 }
@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/BrunoTulio/pgopher/internal/config"
+	"github.com/BrunoTulio/pgopher/internal/replicate"
+	"github.com/BrunoTulio/pgopher/internal/utils"
+)
+
+const defaultReplicationTimeout = time.Hour
+
+func (s *Scheduler) scheduleReplication() error {
+	if s.opt.Replicator == nil {
+		return nil
+	}
+
+	for _, job := range s.opt.Replication {
+		runner := s.wrapJob(job.Name, s.overlapFor(job.Overlap), 0, func() {
+			s.runReplication(job)
+		})
+
+		for _, schedule := range job.Schedule {
+			cronExpr, err := s.convertCronExp(schedule)
+			if err != nil {
+				return fmt.Errorf("replication %s: failed to convert cron %s: %w", job.Name, schedule, err)
+			}
+
+			id, err := s.cron.AddJob(cronExpr, runner)
+			if err != nil {
+				return fmt.Errorf("replication %s: failed to schedule %s: %w", job.Name, schedule, err)
+			}
+
+			s.jobs = append(s.jobs, JobInfo{
+				ID:       id,
+				Name:     job.Name,
+				Type:     "replication",
+				Schedule: schedule,
+				CronExpr: cronExpr,
+			})
+
+			s.log.Infof("🔁 Scheduled replication %s (%s -> %s) at: %s (cron: %s)", job.Name, job.From, job.To, schedule, cronExpr)
+		}
+	}
+	return nil
+}
+
+func (s *Scheduler) runReplication(job config.ReplicationJob) {
+	s.log.Infof("⏰ Scheduled replication started: %s (%s -> %s)", job.Name, job.From, job.To)
+
+	timeout := defaultReplicationTimeout
+	if job.Timeout > 0 {
+		timeout = time.Duration(job.Timeout) * time.Second
+	}
+
+	filter := replicate.Filter{All: true}
+	if job.MaxAge > 0 {
+		filter = replicate.Filter{Since: time.Now().AddDate(0, 0, -job.MaxAge)}
+	}
+
+	var result replicate.Result
+	err := s.withRetry(job.Name, "replication", s.retryFor(job.Retry), func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		var err error
+		result, err = s.opt.Replicator.Copy(ctx, job.From, job.To, filter)
+		return err
+	})
+	if err != nil {
+		s.log.Errorf("❌ Replication %s failed: %v", job.Name, err)
+		go func() {
+			_ = s.notifier.Error(context.Background(), fmt.Sprintf("❌ Replication %s (%s -> %s) failed: %v", job.Name, job.From, job.To, err))
+		}()
+		return
+	}
+
+	if result.Copied == 0 {
+		s.log.Infof("✅ Replication %s: %s is up to date", job.Name, job.To)
+		return
+	}
+
+	s.log.Infof("✅ Replication %s completed", job.Name)
+	go func() {
+		_ = s.notifier.Success(context.Background(), fmt.Sprintf("✅ Replication %s: %d backup(s) copied to %s (%s)",
+			job.Name, result.Copied, job.To, utils.FormatBytes(result.Bytes)))
+	}()
+}
@@ -0,0 +1,129 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/BrunoTulio/pgopher/internal/backup"
+	"github.com/BrunoTulio/pgopher/internal/config"
+	"github.com/rclone/rclone/fs/fserrors"
+)
+
+const (
+	ErrorClassNetwork = "network"
+	ErrorClassTimeout = "timeout"
+	ErrorClassDump    = "dump"
+	ErrorClassAll     = "all"
+	ErrorClassOther   = "other"
+)
+
+var errRestoreRunning = errors.New("restore in progress")
+
+func (s *Scheduler) retryFor(jobRetry *config.RetryConfig) config.RetryConfig {
+	if jobRetry != nil {
+		return *jobRetry
+	}
+	return s.opt.Scheduler.Retry
+}
+
+// withRetry runs fn until it succeeds, the error is not retryable or the attempts are
+// exhausted, sleeping with exponential backoff between attempts. Every attempt is recorded.
+// Each attempt holds a max_concurrent slot, released during the backoff so a job waiting to
+// retry does not hold back the others. Stop ends the backoff with the last error
+func (s *Scheduler) withRetry(name, jobType string, policy config.RetryConfig, fn func() error) error {
+	maxAttempts := policy.Attempts()
+	delay := policy.Initial()
+
+	for attempt := 1; ; attempt++ {
+		s.acquireSlot(name)
+		start := time.Now()
+		err := fn()
+		s.releaseSlot()
+
+		final := err == nil || attempt >= maxAttempts || !isRetryable(err, policy.Classes())
+		s.recordJob(name, jobType, start, attempt, final, err)
+
+		if final {
+			if err != nil && attempt > 1 {
+				return fmt.Errorf("failed after %d attempt(s): %w", attempt, err)
+			}
+			return err
+		}
+
+		s.log.Warnf("🔁 Job %s attempt %d/%d failed (%s): %v — retrying in %s",
+			name, attempt, maxAttempts, classifyError(err), err, delay.Round(time.Second))
+		select {
+		case <-time.After(delay):
+		case <-s.stopCtx.Done():
+			s.log.Warnf("⚠️  Scheduler stopping, cancelling retries of %s", name)
+			return fmt.Errorf("retry of %s cancelled, scheduler stopping: %w", name, err)
+		}
+
+		if s.locker.IsRestoreRunning() {
+			s.log.Warnf("⚠️  Restore in progress, cancelling retries of %s", name)
+			return fmt.Errorf("%w: retry of %s cancelled: %v", errRestoreRunning, name, err)
+		}
+
+		delay = time.Duration(float64(delay) * policy.Factor())
+		if delay > policy.Max() {
+			delay = policy.Max()
+		}
+	}
+}
+
+func isRetryable(err error, classes []string) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	class := classifyError(err)
+	for _, c := range classes {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if c == ErrorClassAll || c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// classifyError maps an error to network, timeout, dump or other
+func classifyError(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, syscall.ETIMEDOUT) {
+		return ErrorClassTimeout
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return ErrorClassTimeout
+		}
+		return ErrorClassNetwork
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		fserrors.ShouldRetry(err) {
+		return ErrorClassNetwork
+	}
+
+	// pg_dump reports an unreachable server with its exit code only
+	if errors.Is(err, backup.ErrConnection) {
+		return ErrorClassNetwork
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return ErrorClassDump
+	}
+
+	return ErrorClassOther
+}
@@ -9,9 +9,12 @@ import (
 	"github.com/BrunoTulio/logr"
 	"github.com/BrunoTulio/pgopher/internal/backup"
 	"github.com/BrunoTulio/pgopher/internal/config"
+	"github.com/BrunoTulio/pgopher/internal/digest"
+	"github.com/BrunoTulio/pgopher/internal/heartbeat"
 	"github.com/BrunoTulio/pgopher/internal/lock"
 	"github.com/BrunoTulio/pgopher/internal/notify"
 	"github.com/BrunoTulio/pgopher/internal/remote"
+	"github.com/BrunoTulio/pgopher/internal/utils"
 
 	"github.com/robfig/cron/v3"
 )
@@ -34,6 +37,11 @@ type Scheduler struct {
 	notifier    notify.Notifier
 	locker      lock.Locker
 	jobs        []JobInfo
+	cronLog     cron.Logger
+	slots       chan struct{}       // limits concurrent jobs, nil = unlimited
+	runners     map[string]cron.Job // wrapped job per name, shared by all its schedules
+	stopCtx     context.Context     // done once Stop is called, ends the waits between attempts
+	stop        context.CancelFunc
 }
 
 func New(backupSvc *backup.Local,
@@ -57,11 +65,21 @@ func NewWithOptions(
 		fn(opt)
 	}
 
+	cronLog := cron.VerbosePrintfLogger(&wrapLogger{log})
 	c := cron.New(
 		cron.WithLocation(opt.timezone),
-		cron.WithLogger(cron.VerbosePrintfLogger(&wrapLogger{log})),
+		cron.WithParser(utils.CronParser()),
+		cron.WithLogger(cronLog),
+		cron.WithChain(cron.Recover(cronLog)),
 	)
 
+	var slots chan struct{}
+	if opt.Scheduler.MaxConcurrent > 0 {
+		slots = make(chan struct{}, opt.Scheduler.MaxConcurrent)
+	}
+
+	stopCtx, stop := context.WithCancel(context.Background())
+
 	return &Scheduler{
 		cron:      c,
 		opt:       opt,
@@ -69,6 +87,11 @@ func NewWithOptions(
 		log:       log,
 		notifier:  notifier,
 		locker:    locker,
+		cronLog:   cronLog,
+		slots:     slots,
+		runners:   make(map[string]cron.Job),
+		stopCtx:   stopCtx,
+		stop:      stop,
 	}
 }
 
@@ -84,15 +107,33 @@ func (s *Scheduler) Start() error {
 		return fmt.Errorf("failed to schedule remote backups: %w", err)
 	}
 
+	if err := s.scheduleReplication(); err != nil {
+		return fmt.Errorf("failed to schedule replication: %w", err)
+	}
+
+	if err := s.scheduleDigest(); err != nil {
+		return fmt.Errorf("failed to schedule digest: %w", err)
+	}
+
+	if err := s.scheduleCleanup(); err != nil {
+		return fmt.Errorf("failed to schedule cleanup: %w", err)
+	}
+
 	s.cron.Start()
 	s.log.Info("✅ Scheduler started successfully")
 
+	if s.opt.Scheduler.CatchUp {
+		go s.catchUp()
+	}
+
 	return nil
 }
 
 func (s *Scheduler) Stop() {
 	s.log.Info("Stopping scheduler...")
 
+	// running jobs are waited for, the ones waiting to retry give up
+	s.stop()
 	ctx := s.cron.Stop()
 	<-ctx.Done()
 
@@ -131,13 +172,30 @@ func (s *Scheduler) GetJobsStatus() []JobStatus {
 			Name:     j.Name,
 			Type:     j.Type,
 			Schedule: j.Schedule,
+			CronExpr: j.CronExpr,
 			Next:     e.Next,
 			Prev:     e.Prev,
+			NextRuns: nextRuns(e, nextRunsCount),
 		})
 	}
 	return res
 }
 
+// nextRuns returns the upcoming n activation times of an entry
+func nextRuns(e cron.Entry, n int) []time.Time {
+	if e.Next.IsZero() {
+		return nil
+	}
+
+	runs := make([]time.Time, 0, n)
+	next := e.Next
+	for i := 0; i < n && !next.IsZero(); i++ {
+		runs = append(runs, next)
+		next = e.Schedule.Next(next)
+	}
+	return runs
+}
+
 func (s *Scheduler) GetRunningJobs() int {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -155,15 +213,17 @@ func (s *Scheduler) scheduleRemoteBackups() error {
 			continue
 		}
 
+		job := s.wrapJob(provider.Name, s.overlapFor(provider.Overlap), s.jitterFor(provider.Jitter, true), func() {
+			s.runRemoteBackup(provider)
+		})
+
 		for _, schedule := range schedules {
 			cronExpr, err := s.convertCronExp(schedule)
 			if err != nil {
 				return fmt.Errorf("provider %s: failed to convert cron %s: %w", provider.Name, schedule, err)
 			}
 
-			id, err := s.cron.AddFunc(cronExpr, func() {
-				s.runRemoteBackup(provider)
-			})
+			id, err := s.cron.AddJob(cronExpr, job)
 
 			if err != nil {
 				return fmt.Errorf("provider %s: failed to schedule %s: %w", provider.Name, schedule, err)
@@ -174,6 +234,7 @@ func (s *Scheduler) scheduleRemoteBackups() error {
 				Name:     provider.Name,
 				Type:     "remote",
 				Schedule: schedule,
+				CronExpr: cronExpr,
 			})
 
 			s.log.Infof("☁️  Scheduled provider backup %s at: %s (cron: %s)", provider.Name, schedule, cronExpr)
@@ -190,6 +251,8 @@ func (s *Scheduler) scheduleLocalBackups() error {
 		return nil
 	}
 
+	job := s.wrapJob("local", s.overlapFor(s.opt.Local.Overlap), s.jitterFor(s.opt.Local.Jitter, false), s.runLocalBackup)
+
 	for _, schedule := range schedules {
 
 		cronExpr, err := s.convertCronExp(schedule)
@@ -198,9 +261,7 @@ func (s *Scheduler) scheduleLocalBackups() error {
 			return fmt.Errorf("failed to convert cron expression: %w", err)
 		}
 
-		id, err := s.cron.AddFunc(cronExpr, func() {
-			s.runLocalBackup()
-		})
+		id, err := s.cron.AddJob(cronExpr, job)
 
 		if err != nil {
 			return fmt.Errorf("failed to schedule backup at %s: %w", schedule, err)
@@ -211,6 +272,7 @@ func (s *Scheduler) scheduleLocalBackups() error {
 			Name:     "local",
 			Type:     "local",
 			Schedule: schedule,
+			CronExpr: cronExpr,
 		})
 
 		s.log.Infof("📅 Scheduled local backup at: %s (cron: %s)", schedule, cronExpr)
@@ -218,6 +280,87 @@ func (s *Scheduler) scheduleLocalBackups() error {
 	return nil
 }
 
+func (s *Scheduler) scheduleDigest() error {
+	if s.opt.Digest == nil {
+		return nil
+	}
+
+	id, err := s.cron.AddFunc(utils.ToCronExpr(s.opt.DigestCron), s.runDigest)
+	if err != nil {
+		return fmt.Errorf("failed to schedule digest at %s: %w", s.opt.DigestCron, err)
+	}
+
+	s.jobs = append(s.jobs, JobInfo{
+		ID:       id,
+		Name:     "digest",
+		Type:     "digest",
+		Schedule: s.opt.DigestCron,
+		CronExpr: utils.ToCronExpr(s.opt.DigestCron),
+	})
+
+	s.log.Infof("📊 Scheduled digest report (cron: %s)", s.opt.DigestCron)
+	return nil
+}
+
+func (s *Scheduler) runDigest() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	if err := s.opt.Digest.Run(ctx); err != nil {
+		s.log.Errorf("❌ Digest report failed: %v", err)
+	}
+}
+
+const (
+	cleanupSchedule = "@hourly"
+	cleanupTimeout  = 10 * time.Minute // per step, a slow step does not starve the next ones
+)
+
+func (s *Scheduler) scheduleCleanup() error {
+	if len(s.opt.Cleanup) == 0 {
+		return nil
+	}
+
+	id, err := s.cron.AddFunc(cleanupSchedule, s.runCleanup)
+	if err != nil {
+		return err
+	}
+
+	s.jobs = append(s.jobs, JobInfo{
+		ID:       id,
+		Name:     "cleanup",
+		Type:     "cleanup",
+		Schedule: cleanupSchedule,
+		CronExpr: cleanupSchedule,
+	})
+
+	s.log.Infof("🧹 Scheduled cleanup (cron: %s)", cleanupSchedule)
+	return nil
+}
+
+func (s *Scheduler) runCleanup() {
+	for _, step := range s.opt.Cleanup {
+		ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+		step(ctx)
+		cancel()
+	}
+}
+
+func (s *Scheduler) recordJob(name, jobType string, start time.Time, attempt int, final bool, err error) {
+	run := digest.JobRun{
+		Name:     name,
+		Type:     jobType,
+		Start:    start,
+		Duration: time.Since(start),
+		Attempt:  attempt,
+		Final:    final,
+	}
+	if err != nil {
+		run.Err = err.Error()
+	}
+	s.opt.Recorder.RecordJob(run)
+}
+
 func (s *Scheduler) runLocalBackup() {
 
 	if s.locker.IsRestoreRunning() {
@@ -237,21 +380,31 @@ func (s *Scheduler) runLocalBackup() {
 
 	s.log.Info("⏰ Scheduled backup local started")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
-	defer cancel()
+	hb := heartbeat.New("local", s.opt.Local.Heartbeat, s.log)
+	hb.Start(context.Background())
+
+	var backupFile string
+	err := s.withRetry("local", "local", s.retryFor(s.opt.Local.Retry), func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), s.opt.Local.TimeoutDuration())
+		defer cancel()
 
-	backupFile, err := s.backupSvc.Run(ctx)
+		var err error
+		backupFile, err = s.backupSvc.Run(ctx)
+		return err
+	})
 	if err != nil {
 		s.log.Errorf("❌ Backup local failed: %v", err)
+		hb.Fail(context.Background(), fmt.Sprintf("Backup local failed: %v", err))
 		go func() {
-			_ = s.notifier.Error(ctx, fmt.Sprintf("❌ Backup local failed: %v", err))
+			_ = s.notifier.Error(context.Background(), fmt.Sprintf("❌ Backup local failed: %v", err))
 		}()
 		return
 	}
 
 	s.log.Infof("✅ Backup local completed: %s", backupFile)
+	hb.Success(context.Background(), fmt.Sprintf("Backup local completed: %s", backupFile))
 	go func() {
-		_ = s.notifier.Success(ctx, fmt.Sprintf("✅ Backup local completed: %s", backupFile))
+		_ = s.notifier.Success(context.Background(), fmt.Sprintf("✅ Backup local completed: %s", backupFile))
 	}()
 }
 
@@ -264,41 +417,54 @@ func (s *Scheduler) runRemoteBackup(remoteProvider config.RemoteProvider) {
 
 	s.log.Infof("☁️  Scheduled cfg backup started: %s", remoteProvider.Name)
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(remoteProvider.Timeout)*time.Second)
-	defer cancel()
+	hb := heartbeat.New(remoteProvider.Name, remoteProvider.Heartbeat, s.log)
+	hb.Start(context.Background())
 
-	provider, err := remote.NewProviderWithOptions( /*s.locker,*/ s.log, remote.WithOptions(remoteProvider, s.opt.Database, s.opt.EncryptionKey))
-	if err != nil {
-		s.log.Errorf("❌ Remote %s provider creation failed: %v", remoteProvider.Name, err)
-		go func() {
-			_ = s.notifier.Error(ctx, fmt.Sprintf("provider %s creation: %v", remoteProvider.Name, err))
-		}()
-		return
-	}
+	err := s.withRetry(remoteProvider.Name, "remote", s.retryFor(remoteProvider.Retry), func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(remoteProvider.Timeout)*time.Second)
+		defer cancel()
+
+		provider, err := remote.NewProviderWithOptions( /*s.locker,*/ s.log,
+			remote.WithOptions(remoteProvider, s.opt.Database, s.opt.EncryptionKey),
+			remote.WithHooks(s.opt.Hooks),
+			remote.WithWorkDir(s.opt.WorkDir),
+		)
+		if err != nil {
+			s.log.Errorf("❌ Remote %s provider creation failed: %v", remoteProvider.Name, err)
+			return fmt.Errorf("provider %s creation: %w", remoteProvider.Name, err)
+		}
 
-	if err := provider.Backup(ctx); err != nil {
+		return provider.Backup(ctx)
+	})
+	if err != nil {
 		s.log.Errorf("❌ Remote %s backup failed: %v", remoteProvider.Name, err)
+		hb.Fail(context.Background(), fmt.Sprintf("Remote %s backup failed: %v", remoteProvider.Name, err))
 		go func() {
-			_ = s.notifier.Error(ctx, fmt.Sprintf("❌ Remote %s backup failed: %v", remoteProvider.Name, err))
+			_ = s.notifier.Error(context.Background(), fmt.Sprintf("❌ Remote %s backup failed: %v", remoteProvider.Name, err))
 		}()
 		return
 	}
 
 	s.log.Infof("✅ Remote %s backup completed", remoteProvider.Name)
+	if s.opt.Catalog != nil {
+		s.opt.Catalog.Invalidate(remoteProvider.Name)
+	}
+	hb.Success(context.Background(), fmt.Sprintf("Remote %s backup completed", remoteProvider.Name))
 
 	go func() {
-		_ = s.notifier.Success(ctx, fmt.Sprintf("✅ Remote %s backup completed", remoteProvider.Name))
+		_ = s.notifier.Success(context.Background(), fmt.Sprintf("✅ Remote %s backup completed", remoteProvider.Name))
 	}()
 
 }
 
+// convertCronExp accepts "HH:MM" (daily), 5/6-field cron expressions and descriptors (@hourly, @every 4h)
 func (s *Scheduler) convertCronExp(schedule string) (string, error) {
-	var hour, minute int
-	if _, err := fmt.Sscanf(schedule, "%d:%d", &hour, &minute); err != nil {
-		s.log.Warnf("failed to parse hour %d:%d: %v", hour, minute, err)
-		return "", fmt.Errorf("failed to parse hour %d:%d: %w", hour, minute, err)
+	cronExpr := utils.ToCronExpr(schedule)
+
+	if _, err := utils.CronParser().Parse(cronExpr); err != nil {
+		s.log.Warnf("failed to parse schedule %s: %v", schedule, err)
+		return "", fmt.Errorf("failed to parse schedule %s: %w", schedule, err)
 	}
 
-	cronExpr := fmt.Sprintf("%d %d * * *", minute, hour)
 	return cronExpr, nil
 }
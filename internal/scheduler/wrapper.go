@@ -0,0 +1,195 @@
+package scheduler
+
+import (
+	"context"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/BrunoTulio/pgopher/internal/utils"
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	OverlapSkip  = "skip"
+	OverlapQueue = "queue"
+)
+
+// wrapJob builds the cron job for a backup: overlap protection (skip or queue while the
+// previous run of the same job is still running) and random jitter. The global concurrency
+// limit is taken per attempt, see withRetry.
+// The returned job must be shared by every schedule of the same name.
+func (s *Scheduler) wrapJob(name, overlap string, jitter time.Duration, fn func()) cron.Job {
+	overlapWrapper := cron.SkipIfStillRunning(s.cronLog)
+	if overlap == OverlapQueue {
+		overlapWrapper = cron.DelayIfStillRunning(s.cronLog)
+	}
+
+	job := cron.NewChain(overlapWrapper).Then(cron.FuncJob(func() {
+		if !s.sleepJitter(name, jitter) {
+			return
+		}
+		fn()
+	}))
+
+	s.runners[name] = job
+	return job
+}
+
+func (s *Scheduler) overlapFor(jobOverlap string) string {
+	overlap := strings.ToLower(jobOverlap)
+	if overlap == "" {
+		overlap = strings.ToLower(s.opt.Scheduler.Overlap)
+	}
+	if overlap == OverlapQueue {
+		return OverlapQueue
+	}
+	return OverlapSkip
+}
+
+// jitterFor returns the job jitter, falling back to the global one for remote jobs only
+func (s *Scheduler) jitterFor(jobJitter *int, remote bool) time.Duration {
+	if jobJitter != nil {
+		return time.Duration(*jobJitter) * time.Second
+	}
+	if remote {
+		return time.Duration(s.opt.Scheduler.Jitter) * time.Second
+	}
+	return 0
+}
+
+// sleepJitter waits a random delay below jitter, false when the scheduler stopped meanwhile
+func (s *Scheduler) sleepJitter(name string, jitter time.Duration) bool {
+	if jitter <= 0 {
+		return true
+	}
+
+	delay := rand.N(jitter)
+	s.log.Infof("🎲 Job %s delayed by %s (jitter)", name, delay.Round(time.Second))
+
+	select {
+	case <-time.After(delay):
+		return true
+	case <-s.stopCtx.Done():
+		s.log.Warnf("⚠️  Scheduler stopping, job %s skipped", name)
+		return false
+	}
+}
+
+func (s *Scheduler) acquireSlot(name string) {
+	if s.slots == nil {
+		return
+	}
+
+	select {
+	case s.slots <- struct{}{}:
+	default:
+		s.log.Infof("⏳ Job %s waiting for a free slot (max_concurrent=%d)", name, cap(s.slots))
+		s.slots <- struct{}{}
+	}
+}
+
+func (s *Scheduler) releaseSlot() {
+	if s.slots == nil {
+		return
+	}
+	<-s.slots
+}
+
+// catchUp runs, once on startup, every job whose newest backup is older than its
+// most recent expected activation (runs missed while the daemon was down).
+func (s *Scheduler) catchUp() {
+	if s.opt.Catalog == nil {
+		s.log.Warn("⚠️  Catch-up enabled but no catalog configured, skipping")
+		return
+	}
+
+	now := time.Now().In(s.opt.timezone)
+	expected := make(map[string]time.Time)
+	order := make([]string, 0)
+
+	for _, j := range s.jobs {
+		if j.Type != "local" && j.Type != "remote" {
+			continue
+		}
+
+		sched, err := utils.CronParser().Parse(j.CronExpr)
+		if err != nil {
+			continue
+		}
+
+		prev := previousActivation(sched, now)
+		if _, ok := expected[j.Name]; !ok {
+			order = append(order, j.Name)
+		}
+		if prev.After(expected[j.Name]) {
+			expected[j.Name] = prev
+		}
+	}
+
+	for _, name := range order {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		files, err := s.opt.Catalog.List(ctx, name)
+		cancel()
+		if err != nil {
+			s.log.Warnf("⚠️  Catch-up: failed to list %s: %v", name, err)
+			continue
+		}
+
+		var newest time.Time
+		for _, f := range files {
+			if f.Time.After(newest) {
+				newest = f.Time
+			}
+		}
+
+		if !newest.Before(expected[name]) {
+			continue
+		}
+
+		job, ok := s.runners[name]
+		if !ok {
+			continue
+		}
+
+		if newest.IsZero() {
+			s.log.Infof("⏪ Catch-up: no backup found for %s, running now", name)
+		} else {
+			s.log.Infof("⏪ Catch-up: last backup of %s is from %s (expected run at %s), running now",
+				name, utils.FormatTime(newest), utils.FormatTime(expected[name]))
+		}
+
+		go job.Run()
+	}
+}
+
+// lookbacks are the windows searched for the previous activation, widened until one holds
+// an activation; cron gives up on schedules without one within five years
+var lookbacks = []time.Duration{
+	24 * time.Hour,
+	7 * 24 * time.Hour,
+	31 * 24 * time.Hour,
+	366 * 24 * time.Hour,
+	5 * 366 * 24 * time.Hour,
+}
+
+// previousActivation returns the most recent activation before now, walking the schedule
+// forward from the start of the window: the gaps of a schedule are not all equal (ex:
+// "02:00" and "06:00" run 4h then 20h apart, "0 3 * * 1,4" 3 then 4 days apart)
+func previousActivation(sched cron.Schedule, now time.Time) time.Time {
+	for _, lookback := range lookbacks {
+		prev := sched.Next(now.Add(-lookback))
+		if prev.IsZero() || !prev.Before(now) {
+			continue
+		}
+
+		for {
+			next := sched.Next(prev)
+			if next.IsZero() || !next.Before(now) {
+				return prev
+			}
+			prev = next
+		}
+	}
+	return time.Time{}
+}
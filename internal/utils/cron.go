@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/robfig/cron/v3"
+)
+
+var (
+	timeOfDayRegex = regexp.MustCompile(`^([0-1]?[0-9]|2[0-3]):([0-5][0-9])$`)
+
+	// scheduleParser accepts 5 or 6 field (with seconds) expressions and descriptors (@hourly, @every 4h)
+	scheduleParser = cron.NewParser(
+		cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+	)
+)
+
+// CronParser returns the parser used for every schedule in the config
+func CronParser() cron.Parser {
+	return scheduleParser
+}
+
+// ToCronExpr converts "HH:MM" into a daily cron expression, other values are returned as-is
+func ToCronExpr(schedule string) string {
+	schedule = strings.TrimSpace(schedule)
+
+	m := timeOfDayRegex.FindStringSubmatch(schedule)
+	if m == nil {
+		return schedule
+	}
+
+	var hour, minute int
+	_, _ = fmt.Sscanf(m[1], "%d", &hour)
+	_, _ = fmt.Sscanf(m[2], "%d", &minute)
+
+	return fmt.Sprintf("%d %d * * *", minute, hour)
+}
+
+// ParseSchedule parses "HH:MM", cron expressions and descriptors
+func ParseSchedule(schedule string) (cron.Schedule, error) {
+	if strings.TrimSpace(schedule) == "" {
+		return nil, fmt.Errorf("empty schedule")
+	}
+
+	return scheduleParser.Parse(ToCronExpr(schedule))
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"time"
+	"unicode/utf8"
 )
 
 // FormatBytes formata bytes em formato legível
@@ -35,3 +36,16 @@ func FormatDuration(d time.Duration) string {
 	}
 	return fmt.Sprintf("%d second(s)", int(d.Seconds()))
 }
+
+// Truncate cuts s to at most limit bytes without splitting a rune. Limits in characters
+// (chat APIs) are never exceeded by a byte count
+func Truncate(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	limit = max(limit, 0)
+	for limit > 0 && !utf8.RuneStart(s[limit]) {
+		limit--
+	}
+	return s[:limit]
+}
@@ -1,6 +1,9 @@
 package utils
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 var (
 	configuredLocation *time.Location
@@ -18,3 +21,27 @@ func FormatTime(t time.Time) string {
 	}
 	return t.In(configuredLocation).Format(configuredFormat)
 }
+
+var parseLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// ParseTime parses a user supplied time in the configured timezone,
+// ex: "2026-10-01 03:00", "2026-10-01" or RFC3339
+func ParseTime(value string) (time.Time, error) {
+	loc := configuredLocation
+	if loc == nil {
+		loc = time.Local
+	}
+
+	for _, layout := range parseLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, expected \"YYYY-MM-DD HH:MM[:SS]\", \"YYYY-MM-DD\" or RFC3339", value)
+}
@@ -0,0 +1,44 @@
+package wal
+
+import "github.com/BrunoTulio/pgopher/internal/config"
+
+type (
+	FnOptions func(*Options)
+
+	Options struct {
+		Local         bool   // keep segments in Dir
+		Dir           string // local archive
+		Providers     []config.RemoteProvider
+		Database      config.DatabaseConfig
+		EncryptionKey string
+	}
+)
+
+func WithConfig(cfg *config.Config) FnOptions {
+	return func(opt *Options) {
+		opt.Local = cfg.WAL.Local
+		opt.Dir = cfg.WALDir()
+		opt.Database = cfg.Database
+		opt.EncryptionKey = cfg.EncryptionKey
+
+		for _, name := range cfg.WAL.Providers {
+			for _, provider := range cfg.RemoteProviders {
+				if provider.Name == name {
+					opt.Providers = append(opt.Providers, provider)
+				}
+			}
+		}
+	}
+}
+
+func (o *Options) IsEncryptEnabled() bool {
+	return o.EncryptionKey != ""
+}
+
+// ext is appended to the WAL file names in the archive
+func (o *Options) ext() string {
+	if o.IsEncryptEnabled() {
+		return ".gz.age"
+	}
+	return ".gz"
+}
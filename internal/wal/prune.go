@@ -0,0 +1,201 @@
+package wal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BrunoTulio/pgopher/internal/catalog"
+	"github.com/BrunoTulio/pgopher/internal/config"
+	"github.com/BrunoTulio/pgopher/internal/remote"
+	"github.com/BrunoTulio/pgopher/internal/utils"
+)
+
+type archived struct {
+	Name    string // WAL file name, without the archive extension
+	ModTime time.Time
+	stored  string
+}
+
+// Prune removes the WAL that no kept base backup needs. The boundary is the newest backup
+// history file (<segment>.<offset>.backup, archived by PostgreSQL at the end of every base
+// backup) not newer than the oldest physical backup in the catalog, segments before it
+// are removed from every archive. Without physical backups nothing is removed.
+func (a *Archive) Prune(ctx context.Context, cat *catalog.Catalog) error {
+	oldest, err := oldestBaseBackup(ctx, cat)
+	if err != nil {
+		return err
+	}
+	if oldest.IsZero() {
+		a.log.Info("🧹 WAL prune: no physical base backup, nothing to remove")
+		return nil
+	}
+
+	a.log.Infof("🧹 WAL prune: oldest base backup %s", utils.FormatTime(oldest))
+
+	if a.opt.Local {
+		if err := a.pruneLocal(oldest); err != nil {
+			return fmt.Errorf("prune local: %w", err)
+		}
+	}
+
+	for _, providerCfg := range a.opt.Providers {
+		if err := a.pruneRemote(ctx, providerCfg, oldest); err != nil {
+			return fmt.Errorf("prune %s: %w", providerCfg.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (a *Archive) pruneLocal(oldest time.Time) error {
+	entries, err := os.ReadDir(a.opt.Dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var files []archived
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || entry.IsDir() {
+			continue
+		}
+		files = append(files, newArchived(entry.Name(), info.ModTime()))
+	}
+
+	removed := 0
+	for _, f := range prunable(files, oldest) {
+		if err := os.Remove(filepath.Join(a.opt.Dir, f.stored)); err != nil {
+			a.log.Warnf("⚠️  Failed to remove %s: %v", f.stored, err)
+			continue
+		}
+		removed++
+	}
+
+	a.log.Infof("   local: removed %d WAL file(s)", removed)
+	return nil
+}
+
+func (a *Archive) pruneRemote(ctx context.Context, providerCfg config.RemoteProvider, oldest time.Time) error {
+	provider, err := a.provider(providerCfg)
+	if err != nil {
+		return err
+	}
+
+	entries, err := provider.ListDir(ctx, remoteDir)
+	if err != nil {
+		return err
+	}
+
+	files := make([]archived, 0, len(entries))
+	for _, entry := range entries {
+		files = append(files, newArchived(entry.Name, entry.ModTime))
+	}
+
+	removed, locked := 0, 0
+	for _, f := range prunable(files, oldest) {
+		err := provider.Delete(ctx, path.Join(remoteDir, f.stored))
+		if errors.Is(err, remote.ErrObjectLocked) {
+			locked++ // pruned on a later run, once the retention expires
+			continue
+		}
+		if err != nil {
+			a.log.Warnf("⚠️  %v", err)
+			continue
+		}
+		removed++
+	}
+
+	if locked > 0 {
+		a.log.Infof("   %s: removed %d WAL file(s), %d still locked", providerCfg.Name, removed, locked)
+		return nil
+	}
+	a.log.Infof("   %s: removed %d WAL file(s)", providerCfg.Name, removed)
+	return nil
+}
+
+// oldestBaseBackup returns the time of the oldest physical backup of every location, a
+// location that cannot be listed aborts the prune rather than risk removing needed WAL
+func oldestBaseBackup(ctx context.Context, cat *catalog.Catalog) (time.Time, error) {
+	var oldest time.Time
+
+	for _, provider := range cat.Providers() {
+		files, err := cat.List(ctx, provider)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("list %s: %w", provider, err)
+		}
+		for _, f := range files {
+			if !f.Physical || f.Tag != "" {
+				continue
+			}
+			if oldest.IsZero() || f.Time.Before(oldest) {
+				oldest = f.Time
+			}
+		}
+	}
+
+	return oldest, nil
+}
+
+func newArchived(stored string, modTime time.Time) archived {
+	name := strings.TrimSuffix(strings.TrimSuffix(stored, ".age"), ".gz")
+	return archived{Name: name, ModTime: modTime, stored: stored}
+}
+
+// prunable returns the files before the backup history file of the oldest base backup.
+// Like pg_archivecleanup, the timeline (first 8 chars) is ignored in the comparison
+func prunable(files []archived, oldest time.Time) []archived {
+	var boundary string
+	for _, f := range files {
+		if !isBackupHistory(f.Name) || f.ModTime.After(oldest) {
+			continue
+		}
+		if boundary == "" || f.Name[8:24] > boundary[8:24] {
+			boundary = f.Name
+		}
+	}
+
+	if boundary == "" {
+		return nil
+	}
+
+	var out []archived
+	for _, f := range files {
+		if isWALFile(f.Name) && f.Name[8:24] < boundary[8:24] {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func isHex(s string) bool {
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789ABCDEF", c) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// isWALFile matches segments, partial segments and backup history files,
+// timeline history files (<timeline>.history) are always kept
+func isWALFile(name string) bool {
+	if len(name) < 24 || !isHex(name[:24]) {
+		return false
+	}
+	rest := name[24:]
+	return rest == "" || rest == ".partial" || isBackupHistory(name)
+}
+
+func isBackupHistory(name string) bool {
+	return len(name) == 24+1+8+len(".backup") && isHex(name[:24]) &&
+		name[24] == '.' && isHex(name[25:33]) && strings.HasSuffix(name, ".backup")
+}
@@ -0,0 +1,141 @@
+package wal
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestIsWALFile(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"000000010000000000000001", true},
+		{"000000010000000000000001.partial", true},
+		{"000000010000000000000002.00000028.backup", true},
+		{"00000002.history", false},
+		{"000000010000000000000001.tmp", false},
+		{"00000001000000000000000g", false},
+		{"0000000100000000000001", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := isWALFile(tt.name); got != tt.want {
+			t.Errorf("isWALFile(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIsBackupHistory(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"000000010000000000000002.00000028.backup", true},
+		{"000000010000000000000002.0000002.backup", false},
+		{"000000010000000000000002.0000002g.backup", false},
+		{"000000010000000000000002.00000028.partial", false},
+		{"000000010000000000000002", false},
+		{"00000002.history", false},
+	}
+
+	for _, tt := range tests {
+		if got := isBackupHistory(tt.name); got != tt.want {
+			t.Errorf("isBackupHistory(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPrunable(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time { return base.Add(time.Duration(hours) * time.Hour) }
+
+	tests := []struct {
+		name   string
+		files  []archived
+		oldest time.Time
+		want   []string
+	}{
+		{
+			name: "segments and partial before the boundary",
+			files: []archived{
+				newArchived("000000010000000000000001.gz", at(0)),
+				newArchived("000000010000000000000002.partial.gz", at(1)),
+				newArchived("000000010000000000000003.00000028.backup.gz", at(2)),
+				newArchived("000000010000000000000003.gz", at(2)),
+				newArchived("000000010000000000000004.gz", at(3)),
+			},
+			oldest: at(2),
+			want:   []string{"000000010000000000000001", "000000010000000000000002.partial"},
+		},
+		{
+			name: "timeline history files are kept",
+			files: []archived{
+				newArchived("00000002.history.gz", at(0)),
+				newArchived("000000010000000000000001.gz", at(0)),
+				newArchived("000000020000000000000005.00000028.backup.gz", at(2)),
+			},
+			oldest: at(2),
+			want:   []string{"000000010000000000000001"},
+		},
+		{
+			name: "timelines are ignored in the comparison",
+			files: []archived{
+				newArchived("000000010000000000000003.gz", at(0)),
+				newArchived("000000020000000000000004.gz", at(1)),
+				newArchived("000000010000000000000006.gz", at(1)),
+				newArchived("000000020000000000000005.00000028.backup.gz", at(2)),
+				newArchived("000000020000000000000005.gz", at(2)),
+			},
+			oldest: at(2),
+			want:   []string{"000000010000000000000003", "000000020000000000000004"},
+		},
+		{
+			name: "newest history file not newer than the oldest backup",
+			files: []archived{
+				newArchived("000000010000000000000002.00000028.backup.gz", at(0)),
+				newArchived("000000010000000000000004.00000028.backup.gz", at(1)),
+				newArchived("000000010000000000000006.00000028.backup.gz", at(5)),
+				newArchived("000000010000000000000003.gz", at(0)),
+				newArchived("000000010000000000000005.gz", at(2)),
+			},
+			oldest: at(2),
+			want: []string{
+				"000000010000000000000002.00000028.backup",
+				"000000010000000000000003",
+			},
+		},
+		{
+			name: "no history file older than the oldest base backup",
+			files: []archived{
+				newArchived("000000010000000000000001.gz", at(0)),
+				newArchived("000000010000000000000003.00000028.backup.gz", at(5)),
+			},
+			oldest: at(2),
+			want:   nil,
+		},
+		{
+			name: "encrypted files",
+			files: []archived{
+				newArchived("000000010000000000000001.gz.age", at(0)),
+				newArchived("000000010000000000000002.00000028.backup.gz.age", at(1)),
+			},
+			oldest: at(2),
+			want:   []string{"000000010000000000000001"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, f := range prunable(tt.files, tt.oldest) {
+				got = append(got, f.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("prunable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,349 @@
+package wal
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/pgopher/internal/config"
+	"github.com/BrunoTulio/pgopher/internal/encoder"
+	"github.com/BrunoTulio/pgopher/internal/remote"
+	"github.com/rclone/rclone/fs"
+)
+
+// remoteDir is where the segments are kept, relative to the provider path
+const remoteDir = "wal"
+
+// ErrNotFound is returned by Fetch when no archive has the file, PostgreSQL asks for
+// files that may not exist (ex: the next timeline history) and expects a failure
+var ErrNotFound = errors.New("not found in the archive")
+
+type Archive struct {
+	log logr.Logger
+	opt *Options
+}
+
+func New(log logr.Logger) *Archive {
+	return NewWithOptions(log)
+}
+
+func NewWithOptions(log logr.Logger, opts ...FnOptions) *Archive {
+	opt := &Options{}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	return &Archive{
+		log: log,
+		opt: opt,
+	}
+}
+
+// Push compresses, encrypts and ships a WAL file to every destination (archive_command).
+// Remote uploads happen first, so a segment present in the local archive is complete
+// everywhere and a retry by PostgreSQL is a no-op.
+func (a *Archive) Push(ctx context.Context, walPath string) error {
+	name := filepath.Base(walPath)
+	stored := name + a.opt.ext()
+
+	tmpDir := os.TempDir()
+	if a.opt.Local {
+		if err := os.MkdirAll(a.opt.Dir, 0o700); err != nil {
+			return fmt.Errorf("create %s: %w", a.opt.Dir, err)
+		}
+		if _, err := os.Stat(filepath.Join(a.opt.Dir, stored)); err == nil {
+			same, err := a.sameContent(filepath.Join(a.opt.Dir, stored), walPath)
+			if err != nil {
+				return fmt.Errorf("compare %s with the archived copy: %w", name, err)
+			}
+			// archive_command must fail rather than keep another cluster's or timeline's segment
+			if !same {
+				return fmt.Errorf("%s is already archived with a different content", name)
+			}
+			a.log.Warnf("⚠️  %s already archived, skipping", name)
+			return nil
+		}
+		tmpDir = a.opt.Dir // same filesystem, the final rename is atomic
+	}
+
+	tmp, err := os.CreateTemp(tmpDir, "."+name+"-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if err := a.encode(walPath, tmp); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("compress %s: %w", name, err)
+	}
+	// PostgreSQL recycles the segment once archive_command succeeds, the local copy must be
+	// on disk before it is renamed into the archive
+	if a.opt.Local {
+		if err := tmp.Sync(); err != nil {
+			_ = tmp.Close()
+			return fmt.Errorf("sync %s: %w", name, err)
+		}
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("compress %s: %w", name, err)
+	}
+
+	for _, providerCfg := range a.opt.Providers {
+		if err := a.upload(ctx, providerCfg, tmp.Name(), stored); err != nil {
+			return fmt.Errorf("upload %s to %s: %w", name, providerCfg.Name, err)
+		}
+	}
+
+	if a.opt.Local {
+		if err := os.Rename(tmp.Name(), filepath.Join(a.opt.Dir, stored)); err != nil {
+			return fmt.Errorf("store %s: %w", name, err)
+		}
+		if err := syncDir(a.opt.Dir); err != nil {
+			return fmt.Errorf("sync %s: %w", a.opt.Dir, err)
+		}
+	}
+
+	a.log.Infof("📤 Archived %s", name)
+	return nil
+}
+
+// syncDir flushes a directory entry, a rename is only durable once its directory is synced
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = d.Close()
+	}()
+	return d.Sync()
+}
+
+// Fetch restores a WAL file from the first archive that has it (restore_command),
+// the local archive is tried before the providers
+func (a *Archive) Fetch(ctx context.Context, name, dest string) error {
+	var lastErr error
+
+	for _, ext := range []string{".gz.age", ".gz"} {
+		stored := name + ext
+
+		if a.opt.Local {
+			f, err := os.Open(filepath.Join(a.opt.Dir, stored))
+			if err == nil {
+				err = a.decode(f, stored, dest)
+				_ = f.Close()
+				if err == nil {
+					a.log.Infof("📥 Restored %s from local", name)
+					return nil
+				}
+			}
+			if !os.IsNotExist(err) {
+				lastErr = err
+			}
+		}
+
+		for _, providerCfg := range a.opt.Providers {
+			err := a.download(ctx, providerCfg, stored, dest)
+			if err == nil {
+				a.log.Infof("📥 Restored %s from %s", name, providerCfg.Name)
+				return nil
+			}
+			if !errors.Is(err, fs.ErrorObjectNotFound) {
+				a.log.Warnf("⚠️  Fetch %s from %s: %v", name, providerCfg.Name, err)
+				lastErr = err
+			}
+		}
+	}
+
+	if lastErr != nil {
+		return fmt.Errorf("fetch %s: %w", name, lastErr)
+	}
+	return fmt.Errorf("%s: %w", name, ErrNotFound)
+}
+
+func (a *Archive) provider(providerCfg config.RemoteProvider) (*remote.Provider, error) {
+	provider, err := remote.NewProviderWithOptions(a.log,
+		remote.WithOptions(providerCfg, a.opt.Database, a.opt.EncryptionKey))
+	if err != nil {
+		return nil, fmt.Errorf("new remote provider: %w", err)
+	}
+	return provider, nil
+}
+
+func (a *Archive) upload(ctx context.Context, providerCfg config.RemoteProvider, localPath, stored string) error {
+	provider, err := a.provider(providerCfg)
+	if err != nil {
+		return err
+	}
+
+	return provider.Upload(ctx, localPath, path.Join(remoteDir, stored))
+}
+
+func (a *Archive) download(ctx context.Context, providerCfg config.RemoteProvider, stored, dest string) error {
+	provider, err := a.provider(providerCfg)
+	if err != nil {
+		return err
+	}
+
+	reader, err := provider.Open(ctx, path.Join(remoteDir, stored))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	return a.decode(reader, stored, dest)
+}
+
+// encode compresses (and encrypts) src into dst, dst is left open for the caller to sync
+func (a *Archive) encode(src string, dst *os.File) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	var (
+		out     io.Writer = dst
+		closers []io.Closer
+	)
+
+	if a.opt.IsEncryptEnabled() {
+		enc, err := encoder.NewEncryptor(a.opt.EncryptionKey)
+		if err != nil {
+			return fmt.Errorf("failed to create encryptor: %w", err)
+		}
+		ageWriter, err := enc.NewWriter(dst)
+		if err != nil {
+			return fmt.Errorf("failed to create age writer: %w", err)
+		}
+		closers = append(closers, ageWriter)
+		out = ageWriter
+	}
+
+	gz := gzip.NewWriter(out)
+	closers = append([]io.Closer{gz}, closers...)
+
+	_, err = io.Copy(gz, in)
+	for _, c := range closers {
+		if closeErr := c.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// decode writes the plain WAL file to dest, through a temp file so PostgreSQL never
+// reads a partial segment
+func (a *Archive) decode(input io.Reader, stored, dest string) error {
+	gzReader, err := a.decodeReader(input, stored)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = gzReader.Close()
+	}()
+
+	tmpPath := dest + ".pgopher"
+	out, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, gzReader); err != nil {
+		_ = out.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	return os.Rename(tmpPath, dest)
+}
+
+// decodeReader decrypts (for .age files) and decompresses a stored file
+func (a *Archive) decodeReader(input io.Reader, stored string) (*gzip.Reader, error) {
+	reader := input
+
+	if strings.HasSuffix(stored, ".age") {
+		if !a.opt.IsEncryptEnabled() {
+			return nil, fmt.Errorf("%s is encrypted but no encryption key configured", stored)
+		}
+		enc, err := encoder.NewEncryptor(a.opt.EncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create encryptor: %w", err)
+		}
+		reader, err = enc.DecryptReader(input)
+		if err != nil {
+			return nil, fmt.Errorf("decryption failed: %w", err)
+		}
+	}
+
+	gzReader, err := gzip.NewReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gzip reader: %w", err)
+	}
+	return gzReader, nil
+}
+
+// sameContent reports whether the archived file holds the same data as walPath
+func (a *Archive) sameContent(archived, walPath string) (bool, error) {
+	f, err := os.Open(archived)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	gzReader, err := a.decodeReader(f, archived)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = gzReader.Close()
+	}()
+
+	archivedSum, err := sha256Sum(gzReader)
+	if err != nil {
+		return false, err
+	}
+
+	in, err := os.Open(walPath)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	walSum, err := sha256Sum(in)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(archivedSum, walSum), nil
+}
+
+func sha256Sum(r io.Reader) ([]byte, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}